@@ -0,0 +1,60 @@
+package spaniel
+
+import (
+	"errors"
+	"time"
+)
+
+// Boundary returns the first boundary strictly after the time t, such as the start of the next day, as seen in the
+// location loc. Boundaries are used by Split to cut spans into calendar periods.
+type Boundary func(t time.Time, loc *time.Location) time.Time
+
+// HourBoundary returns the start of the hour after t in the location loc. The hours are those of the local clock, so
+// in a location which is offset from UTC by a fraction of an hour, they fall part way through UTC hours.
+func HourBoundary(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	elapsed := time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second +
+		time.Duration(local.Nanosecond())
+	// The hour is found by subtracting the time elapsed since it began, rather than with time.Date, as an hour may
+	// occur twice when the clocks go back.
+	return local.Add(time.Hour - elapsed)
+}
+
+// DayBoundary returns the midnight following t in the location loc. Days are not always 24 hours long, as the clocks
+// may change during them. If midnight doesn't occur because the clocks go forward then, the day starts at the first
+// time after it which does.
+func DayBoundary(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
+
+// WeekBoundary returns the midnight at the start of the Monday following t in the location loc, so that weeks run
+// from Monday to Sunday as in ISO 8601.
+func WeekBoundary(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	days := (int(time.Monday) - int(local.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	year, month, day := local.Date()
+	return time.Date(year, month, day+days, 0, 0, 0, 0, loc)
+}
+
+// MonthBoundary returns the midnight at the start of the first day of the month following t in the location loc.
+func MonthBoundary(t time.Time, loc *time.Location) time.Time {
+	year, month, _ := t.In(loc).Date()
+	return time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+}
+
+// DurationBoundary returns a Boundary which falls at every multiple of d since the zero time, so that, for example,
+// a d of 15 minutes gives boundaries on each quarter hour. The location is ignored, so d should divide an hour evenly
+// for the boundaries to line up with the local clock in locations offset from UTC by a fraction of an hour. It panics
+// if d is not positive, as the boundaries would then never move on.
+func DurationBoundary(d time.Duration) Boundary {
+	if d <= 0 {
+		panic(errors.New("spaniel: non-positive duration for DurationBoundary"))
+	}
+	return func(t time.Time, loc *time.Location) time.Time {
+		return t.Truncate(d).Add(d)
+	}
+}
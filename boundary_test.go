@@ -0,0 +1,129 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestBoundaries(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+	kolkata := loadLocation(t, "Asia/Kolkata")
+
+	for _, tt := range []struct {
+		name     string
+		boundary timespan.Boundary
+		loc      *time.Location
+		t        time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Should return the next hour",
+			boundary: timespan.HourBoundary,
+			loc:      time.UTC,
+			t:        time.Date(2021, 6, 1, 10, 15, 30, 5, time.UTC),
+			expected: time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the hour after a time on the hour",
+			boundary: timespan.HourBoundary,
+			loc:      time.UTC,
+			t:        time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the next local hour in a location offset by half an hour",
+			boundary: timespan.HourBoundary,
+			loc:      kolkata,
+			t:        time.Date(2021, 6, 1, 10, 15, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the repeated hour when the clocks go back",
+			boundary: timespan.HourBoundary,
+			loc:      london,
+			t:        time.Date(2021, 10, 31, 0, 30, 0, 0, time.UTC),
+			expected: time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the next multiple of a duration",
+			boundary: timespan.DurationBoundary(15 * time.Minute),
+			loc:      kolkata,
+			t:        time.Date(2021, 6, 1, 10, 20, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the next local midnight",
+			boundary: timespan.DayBoundary,
+			loc:      london,
+			t:        time.Date(2021, 6, 1, 23, 30, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 2, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the midnight after a short day",
+			boundary: timespan.DayBoundary,
+			loc:      london,
+			t:        time.Date(2021, 3, 28, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 3, 28, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the next Monday",
+			boundary: timespan.WeekBoundary,
+			loc:      time.UTC,
+			t:        time.Date(2021, 6, 6, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the Monday after a Monday",
+			boundary: timespan.WeekBoundary,
+			loc:      time.UTC,
+			t:        time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 6, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the start of the next month",
+			boundary: timespan.MonthBoundary,
+			loc:      london,
+			t:        time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Should return the start of the next year",
+			boundary: timespan.MonthBoundary,
+			loc:      time.UTC,
+			t:        time.Date(2021, 12, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.boundary(tt.t, tt.loc)
+			if !actual.Equal(tt.expected) {
+				t.Fatalf("Expected %v to equal %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDurationBoundary(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Hour} {
+		t.Run("Should panic for a duration of "+d.String(), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			timespan.DurationBoundary(d)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Bucket represents one of the periods a window has been divided into, along with the amount of time within it which
+// is covered by spans.
+type Bucket struct {
+	Span
+	// Covered is the amount of time within the bucket which is covered by spans.
+	Covered time.Duration
+}
+
+// Buckets divides the window into periods at the boundaries given by the boundary function in the location loc, and
+// returns a Bucket for each of them, with the amount of time within it covered by the contained spans. The spans are
+// merged as by Union first, so that time covered by more than one of them is only counted once; see RawBuckets.
+// For example, calling Buckets with a window covering the last 30 days and HourBoundary returns the time covered by
+// the spans in each hour. The buckets are the spans returned by calling Split on the window.
+func (s Spans) Buckets(window Span, boundary Boundary, loc *time.Location) []Bucket {
+	return s.Union().bucketed(window, boundary, loc)
+}
+
+// RawBuckets divides the window into periods in the same way as Buckets, but counts the time covered by each of the
+// contained spans separately, so that time covered by two overlapping spans is counted twice.
+func (s Spans) RawBuckets(window Span, boundary Boundary, loc *time.Location) []Bucket {
+	return s.bucketed(window, boundary, loc)
+}
+
+func (s Spans) bucketed(window Span, boundary Boundary, loc *time.Location) []Bucket {
+	periods := Spans{window}.Split(boundary, loc)
+	buckets := make([]Bucket, len(periods))
+	for i, period := range periods {
+		buckets[i].Span = period
+	}
+
+	for _, span := range s {
+		if !span.Start().Before(span.End()) {
+			continue
+		}
+
+		// Add the time the span covers in each bucket, starting with the first which finishes after the span starts.
+		i := sort.Search(len(buckets), func(i int) bool { return buckets[i].End().After(span.Start()) })
+		for ; i < len(buckets) && buckets[i].Start().Before(span.End()); i++ {
+			from, to := span.Start(), span.End()
+			if buckets[i].Start().After(from) {
+				from = buckets[i].Start()
+			}
+			if buckets[i].End().Before(to) {
+				to = buckets[i].End()
+			}
+			buckets[i].Covered += to.Sub(from)
+		}
+	}
+	return buckets
+}
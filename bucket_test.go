@@ -0,0 +1,58 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestBuckets(t *testing.T) {
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	window := timespan.New(day, day.Add(4*time.Hour))
+	spans := timespan.Spans{
+		timespan.New(day.Add(-30*time.Minute), day.Add(15*time.Minute)),
+		timespan.New(day.Add(50*time.Minute), day.Add(70*time.Minute)),
+		timespan.New(day.Add(60*time.Minute), day.Add(80*time.Minute)),
+		timespan.NewInstant(day.Add(3 * time.Hour)),
+		timespan.New(day.Add(230*time.Minute), day.Add(5*time.Hour)),
+	}
+
+	hours := func(covered ...time.Duration) []timespan.Bucket {
+		var buckets []timespan.Bucket
+		for i, c := range covered {
+			start := day.Add(time.Duration(i) * time.Hour)
+			buckets = append(buckets, timespan.Bucket{Span: timespan.New(start, start.Add(time.Hour)), Covered: c})
+		}
+		return buckets
+	}
+
+	t.Run("Should return the time covered in each bucket", func(t *testing.T) {
+		expectEqual(t, spans.Buckets(window, timespan.HourBoundary, time.UTC), hours(
+			25*time.Minute, 20*time.Minute, 0, 10*time.Minute,
+		))
+	})
+
+	t.Run("Should count overlapping time twice for raw buckets", func(t *testing.T) {
+		expectEqual(t, spans.RawBuckets(window, timespan.HourBoundary, time.UTC), hours(
+			25*time.Minute, 30*time.Minute, 0, 10*time.Minute,
+		))
+	})
+
+	t.Run("Should return empty buckets for no spans", func(t *testing.T) {
+		expectEqual(t, timespan.Spans{}.Buckets(window, timespan.HourBoundary, time.UTC), hours(0, 0, 0, 0))
+	})
+
+	t.Run("Should divide the window into local days", func(t *testing.T) {
+		london := loadLocation(t, "Europe/London")
+		start := time.Date(2021, 3, 27, 0, 0, 0, 0, london)
+		window := timespan.New(start, start.AddDate(0, 0, 3))
+		all := timespan.Spans{window}
+
+		buckets := all.Buckets(window, timespan.DayBoundary, london)
+		expectEqual(t, len(buckets), 3)
+		expectEqual(t, buckets[0].Covered, 24*time.Hour)
+		expectEqual(t, buckets[1].Covered, 23*time.Hour)
+		expectEqual(t, buckets[2].Covered, 24*time.Hour)
+	})
+}
@@ -0,0 +1,29 @@
+package spaniel
+
+import (
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// CoverageSpan represents a span of time throughout which the same number of spans are active. It embeds the Span,
+// and has a Count field giving the number of spans which cover the whole of the span.
+type CoverageSpan = interval.CoverageSpan[time.Time]
+
+// Coverage partitions the time covered by the contained spans into a list of CoverageSpans, each annotated with the
+// number of spans active throughout it.
+// For example, given a list [A,B] where A and B overlap, a list [C,D,E] would be returned, where C covers A up to the
+// start of B with a count of 1, D covers the intersection of A and B with a count of 2, and E covers B from the end of
+// A with a count of 1. The returned spans are sorted, don't overlap one another, and honour the endpoint types of the
+// spans they were derived from, so that spans touching at a single Closed point result in an instant with a count
+// of 2. Time which is not covered by any span is omitted.
+func (s Spans) Coverage() []CoverageSpan {
+	return timeDomain.Coverage(spans(s))
+}
+
+// CoveredBy returns a list of Spans representing the time covered by at least n of the contained spans.
+// For example, given a list [A,B,C] where A and B overlap, but C overlaps neither, a call with n of 2 would return a
+// list [D], with the span D covering the intersection of A and B.
+func (s Spans) CoveredBy(n int) Spans {
+	return Spans(timeDomain.CoveredBy(spans(s), n))
+}
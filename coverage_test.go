@@ -0,0 +1,96 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestCoverage(t *testing.T) {
+
+	t.Run("Should return nothing for no timespans", func(t *testing.T) {
+		expectEqual(t, len(timespan.Spans{}.Coverage()), 0)
+	})
+
+	t.Run("Should count a single timespan once", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		expectEqual(t, timespan.Spans{a}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.New(now, now.Add(time.Hour)), Count: 1},
+		})
+	})
+
+	t.Run("Should count overlapping timespans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(2*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(90*time.Minute), now.Add(2*time.Hour))
+		expectEqual(t, timespan.Spans{c, b, a}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.New(now, now.Add(time.Hour)), Count: 1},
+			{Span: timespan.New(now.Add(time.Hour), now.Add(90*time.Minute)), Count: 2},
+			{Span: timespan.New(now.Add(90*time.Minute), now.Add(2*time.Hour)), Count: 3},
+			{Span: timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)), Count: 1},
+		})
+	})
+
+	t.Run("Should omit gaps between timespans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		expectEqual(t, timespan.Spans{a, b}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.New(now, now.Add(time.Hour)), Count: 1},
+			{Span: timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)), Count: 1},
+		})
+	})
+
+	t.Run("Should join consecutive timespans with the same count", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))
+		expectEqual(t, timespan.Spans{a, b}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.New(now, now.Add(2*time.Hour)), Count: 1},
+		})
+	})
+
+	t.Run("Should count a shared Closed point separately", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed)
+		b := timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Closed, timespan.Closed)
+		expectEqual(t, timespan.Spans{a, b}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open), Count: 1},
+			{Span: timespan.NewWithTypes(now.Add(time.Hour), now.Add(time.Hour), timespan.Closed, timespan.Closed), Count: 2},
+			{Span: timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Open, timespan.Closed), Count: 1},
+		})
+	})
+
+	t.Run("Should count instants", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Open)
+		b := timespan.NewInstant(now)
+		c := timespan.NewInstant(now.Add(30 * time.Minute))
+		expectEqual(t, timespan.Spans{a, b, c}.Coverage(), []timespan.CoverageSpan{
+			{Span: timespan.NewWithTypes(now, now.Add(30*time.Minute), timespan.Closed, timespan.Open), Count: 1},
+			{Span: timespan.NewInstant(now.Add(30 * time.Minute)), Count: 2},
+			{Span: timespan.NewWithTypes(now.Add(30*time.Minute), now.Add(time.Hour), timespan.Open, timespan.Open), Count: 1},
+		})
+	})
+}
+
+func TestCoveredBy(t *testing.T) {
+	a := timespan.New(now, now.Add(2*time.Hour))
+	b := timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))
+	c := timespan.New(now.Add(90*time.Minute), now.Add(4*time.Hour))
+	d := timespan.New(now.Add(5*time.Hour), now.Add(6*time.Hour))
+	spans := timespan.Spans{a, b, c, d}
+
+	t.Run("Should return the union for a count of one", func(t *testing.T) {
+		expectEqual(t, spans.CoveredBy(1), spans.Union())
+	})
+
+	t.Run("Should return the time covered by at least two timespans", func(t *testing.T) {
+		expectEqual(t, spans.CoveredBy(2), timespan.Spans{timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))})
+	})
+
+	t.Run("Should return the time covered by all timespans", func(t *testing.T) {
+		expectEqual(t, spans.CoveredBy(3), timespan.Spans{timespan.New(now.Add(90*time.Minute), now.Add(2*time.Hour))})
+	})
+
+	t.Run("Should return nothing if too few timespans overlap", func(t *testing.T) {
+		expectEqual(t, spans.CoveredBy(4), timespan.Spans{})
+	})
+}
@@ -0,0 +1,62 @@
+package spaniel
+
+import (
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// DifferenceHandlerFunc is used by DifferenceWithHandler to allow for custom functionality when part of a span is
+// removed. It is passed the span which has been cut, and the span representing the fragment that remains.
+type DifferenceHandlerFunc func(subtractFrom, differenceSpan Span) Span
+
+// DifferenceWithHandler returns a list of Spans representing the time covered by the contained spans, but not by
+// any of the spans in other.
+// For example, given a list [A] and another list [B] where B lies within A, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers A from the end of B. The endpoint types of the fragments are taken from
+// the spans they were cut from, so subtracting [1,2) from [1,3] results in [2,3]. The provided handler is passed the
+// span being cut, and the span representing each of the fragments left over. Spans which do not overlap other are
+// returned unchanged.
+func (s Spans) DifferenceWithHandler(other Spans, differenceHandlerFunc DifferenceHandlerFunc) Spans {
+	return Spans(timeDomain.DifferenceWithHandler(spans(s), spans(other), interval.DifferenceHandlerFunc[time.Time](differenceHandlerFunc)))
+}
+
+// Difference returns a list of Spans representing the time covered by the contained spans, but not by any of the
+// spans in other.
+// For example, given a list [A] and another list [B] where B lies within A, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers A from the end of B.
+func (s Spans) Difference(other Spans) Spans {
+	return Spans(timeDomain.Difference(spans(s), spans(other)))
+}
+
+// Complement returns a list of Spans representing the gaps between the contained spans within the given window.
+// For example, given a list [A,B] where A and B are separate and both lie within the window, a list [C,D,E] would be
+// returned, covering the window before A, between A and B, and after B. The endpoint types of the gaps are the
+// opposite of the types of the spans they border, so a Closed end in the list results in an Open start of a gap.
+func (s Spans) Complement(window Span) Spans {
+	return Spans(timeDomain.Complement(spans(s), window))
+}
+
+// SymmetricDifferenceHandlerFunc is used by SymmetricDifferenceWithHandler to allow for custom functionality when
+// time is found to be covered by only one of the two lists. It is passed the span the time was found in, whether that
+// span came from the other list rather than the receiver, and the span representing the time covered by it alone.
+type SymmetricDifferenceHandlerFunc func(source Span, fromOther bool, differenceSpan Span) Span
+
+// SymmetricDifferenceWithHandler returns a list of Spans representing the time covered by either the contained spans
+// or the spans in other, but not by both.
+// For example, given a list [A] and another list [B] where A and B overlap, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers B from the end of A. Each list is merged as by Union before the time
+// covered by the other list is removed from it, so that the handler is passed the merged span each fragment was cut
+// from. Unlike DifferenceWithHandler, the handler is called for every span returned, including spans which the other
+// list does not overlap, so that the side each span came from is always known.
+func (s Spans) SymmetricDifferenceWithHandler(other Spans, symmetricDifferenceHandlerFunc SymmetricDifferenceHandlerFunc) Spans {
+	return Spans(timeDomain.SymmetricDifferenceWithHandler(spans(s), spans(other), interval.SymmetricDifferenceHandlerFunc[time.Time](symmetricDifferenceHandlerFunc)))
+}
+
+// SymmetricDifference returns a list of Spans representing the time covered by either the contained spans or the
+// spans in other, but not by both.
+// For example, given a list [A] and another list [B] where A and B overlap, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers B from the end of A.
+func (s Spans) SymmetricDifference(other Spans) Spans {
+	return Spans(timeDomain.SymmetricDifference(spans(s), spans(other)))
+}
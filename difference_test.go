@@ -0,0 +1,318 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestDifference(t *testing.T) {
+
+	t.Run("Should leave a timespan unchanged if nothing overlaps it", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{a})
+	})
+
+	t.Run("Should return nothing when subtracting from an empty list", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		after := timespan.Spans{}.Difference(timespan.Spans{a})
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should remove a timespan entirely covered by another", func(t *testing.T) {
+		a := timespan.New(now.Add(15*time.Minute), now.Add(30*time.Minute))
+		b := timespan.New(now, now.Add(time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should remove the overlapping end of a timespan", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{timespan.New(now, now.Add(30*time.Minute))})
+	})
+
+	t.Run("Should remove the overlapping start of a timespan", func(t *testing.T) {
+		a := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+		b := timespan.New(now, now.Add(time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))})
+	})
+
+	t.Run("Should split a timespan around a timespan within it", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(15*time.Minute), now.Add(30*time.Minute))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(15*time.Minute)),
+			timespan.New(now.Add(30*time.Minute), now.Add(time.Hour)),
+		})
+	})
+
+	t.Run("Should split a timespan around an instant within it", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.NewInstant(now.Add(30 * time.Minute))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(30*time.Minute)),
+			timespan.NewWithTypes(now.Add(30*time.Minute), now.Add(time.Hour), timespan.Open, timespan.Open),
+		})
+	})
+
+	t.Run("Should subtract overlapping timespans as if they were merged", func(t *testing.T) {
+		a := timespan.New(now, now.Add(3*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(90*time.Minute))
+		c := timespan.New(now.Add(80*time.Minute), now.Add(2*time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{c, b})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)),
+		})
+	})
+
+	t.Run("Should subtract from each timespan in turn", func(t *testing.T) {
+		a := timespan.New(now.Add(2*time.Hour), now.Add(4*time.Hour))
+		b := timespan.New(now, now.Add(2*time.Hour))
+		c := timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))
+		after := timespan.Spans{a, b}.Difference(timespan.Spans{c})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour)),
+		})
+	})
+
+	t.Run("Should not remove anything for a consecutive timespan", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))
+		after := timespan.Spans{a}.Difference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{a})
+	})
+}
+
+func TestDifferenceWithHandler(t *testing.T) {
+	a := NewPropertyEvent(now, now.Add(time.Hour), []string{"prop1"})
+	b := timespan.New(now.Add(15*time.Minute), now.Add(30*time.Minute))
+
+	var cut []timespan.Span
+	after := timespan.Spans{a}.DifferenceWithHandler(timespan.Spans{b}, func(subtractFrom, differenceSpan timespan.Span) timespan.Span {
+		cut = append(cut, subtractFrom)
+		p := subtractFrom.(*PropertyEvent)
+		return NewPropertyEvent(differenceSpan.Start(), differenceSpan.End(), p.Properties)
+	})
+
+	expectEqual(t, cut, []timespan.Span{a, a})
+	expectEqual(t, after, timespan.Spans{
+		NewPropertyEvent(now, now.Add(15*time.Minute), []string{"prop1"}),
+		NewPropertyEvent(now.Add(30*time.Minute), now.Add(time.Hour), []string{"prop1"}),
+	})
+}
+
+func TestTypedDifference(t *testing.T) {
+
+	for _, tt := range []struct {
+		name     string
+		a, b     timespan.Span
+		expected timespan.Spans
+	}{
+		{
+			//  [----a----]
+			//  [-b-)
+			name:     "shared start c/c c/o",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			expected: timespan.Spans{timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Closed)},
+		},
+		{
+			//  [----a----]
+			//  (-b-]
+			name: "shared start c/c o/c",
+			a:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			b:    timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Closed),
+			expected: timespan.Spans{
+				timespan.NewWithTypes(t1, t1, timespan.Closed, timespan.Closed),
+				timespan.NewWithTypes(t2, t3, timespan.Open, timespan.Closed),
+			},
+		},
+		{
+			//  [----a----]
+			//       (-b-)
+			name: "shared end c/c o/o",
+			a:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			b:    timespan.NewWithTypes(t2, t3, timespan.Open, timespan.Open),
+			expected: timespan.Spans{
+				timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Closed),
+				timespan.NewWithTypes(t3, t3, timespan.Closed, timespan.Closed),
+			},
+		},
+		{
+			//  [----a----)
+			//       [-b-]
+			name:     "shared end c/o c/c",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Closed),
+			expected: timespan.Spans{timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open)},
+		},
+		{
+			//  (----a----)
+			//  [----b----]
+			name:     "same o/o c/c",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Open, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			expected: timespan.Spans{},
+		},
+		{
+			//  [----a----]
+			//  (----b----)
+			name: "same c/c o/o",
+			a:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			b:    timespan.NewWithTypes(t1, t3, timespan.Open, timespan.Open),
+			expected: timespan.Spans{
+				timespan.NewWithTypes(t1, t1, timespan.Closed, timespan.Closed),
+				timespan.NewWithTypes(t3, t3, timespan.Closed, timespan.Closed),
+			},
+		},
+		{
+			//  [a]
+			//  [--b--)
+			name:     "instant at start c/o",
+			a:        timespan.NewInstant(t1),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			expected: timespan.Spans{},
+		},
+		{
+			//  [a]
+			//  (--b--)
+			name:     "instant at start o/o",
+			a:        timespan.NewInstant(t1),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Open),
+			expected: timespan.Spans{timespan.NewInstant(t1)},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expectEqual(t, timespan.Spans{tt.a}.Difference(timespan.Spans{tt.b}), tt.expected)
+		})
+	}
+}
+
+func TestComplement(t *testing.T) {
+	window := timespan.New(now, now.Add(4*time.Hour))
+
+	t.Run("Should return the whole window if there are no timespans", func(t *testing.T) {
+		after := timespan.Spans{}.Complement(window)
+		expectEqual(t, after, timespan.Spans{window})
+	})
+
+	t.Run("Should return nothing if the window is covered", func(t *testing.T) {
+		a := timespan.New(now.Add(-time.Hour), now.Add(5*time.Hour))
+		after := timespan.Spans{a}.Complement(window)
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should return the gaps between and around timespans", func(t *testing.T) {
+		a := timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))
+		b := timespan.New(now.Add(3*time.Hour), now.Add(5*time.Hour))
+		c := timespan.New(now.Add(90*time.Minute), now.Add(150*time.Minute))
+		after := timespan.Spans{b, c, a}.Complement(window)
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(150*time.Minute), now.Add(3*time.Hour)),
+		})
+	})
+
+	t.Run("Should not find gaps between consecutive timespans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))
+		after := timespan.Spans{a, b}.Complement(window)
+		expectEqual(t, after, timespan.Spans{timespan.New(now.Add(2*time.Hour), now.Add(4*time.Hour))})
+	})
+
+	t.Run("Should flip the endpoint types of the bordering timespans", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed)
+		b := timespan.NewWithTypes(now.Add(2*time.Hour), now.Add(3*time.Hour), timespan.Open, timespan.Open)
+		after := timespan.Spans{a, b}.Complement(window)
+		expectEqual(t, after, timespan.Spans{
+			timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Open, timespan.Closed),
+			timespan.NewWithTypes(now.Add(3*time.Hour), now.Add(4*time.Hour), timespan.Closed, timespan.Open),
+		})
+	})
+
+	t.Run("Should find an instant gap between two open timespans", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open)
+		b := timespan.NewWithTypes(now.Add(time.Hour), now.Add(4*time.Hour), timespan.Open, timespan.Open)
+		after := timespan.Spans{a, b}.Complement(window)
+		expectEqual(t, after, timespan.Spans{timespan.NewWithTypes(now.Add(time.Hour), now.Add(time.Hour), timespan.Closed, timespan.Closed)})
+	})
+}
+
+func TestSymmetricDifference(t *testing.T) {
+
+	t.Run("Should return both timespans if they are separate", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		after := timespan.Spans{b}.SymmetricDifference(timespan.Spans{a})
+		expectEqual(t, after, timespan.Spans{a, b})
+	})
+
+	t.Run("Should return nothing for identical lists", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		after := timespan.Spans{a}.SymmetricDifference(timespan.Spans{a})
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should return the time covered by only one of two overlapping timespans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(2*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))
+		after := timespan.Spans{a}.SymmetricDifference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)),
+		})
+	})
+
+	t.Run("Should not count time covered twice by the same list", func(t *testing.T) {
+		a := timespan.New(now, now.Add(2*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(90*time.Minute), now.Add(150*time.Minute))
+		after := timespan.Spans{a, b}.SymmetricDifference(timespan.Spans{c})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now, now.Add(90*time.Minute)),
+			timespan.New(now.Add(150*time.Minute), now.Add(3*time.Hour)),
+		})
+	})
+
+	t.Run("Should flip the endpoint types where the lists meet", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(2*time.Hour), timespan.Closed, timespan.Closed)
+		b := timespan.NewWithTypes(now.Add(time.Hour), now.Add(3*time.Hour), timespan.Closed, timespan.Closed)
+		after := timespan.Spans{a}.SymmetricDifference(timespan.Spans{b})
+		expectEqual(t, after, timespan.Spans{
+			timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open),
+			timespan.NewWithTypes(now.Add(2*time.Hour), now.Add(3*time.Hour), timespan.Open, timespan.Closed),
+		})
+	})
+}
+
+func TestSymmetricDifferenceWithHandler(t *testing.T) {
+	operator := NewPropertyEvent(now, now.Add(2*time.Hour), []string{"operator"})
+	sensor := NewPropertyEvent(now.Add(time.Hour), now.Add(3*time.Hour), []string{"sensor"})
+	other := NewPropertyEvent(now.Add(4*time.Hour), now.Add(5*time.Hour), []string{"sensor"})
+
+	var sides []bool
+	after := timespan.Spans{operator}.SymmetricDifferenceWithHandler(timespan.Spans{sensor, other}, func(source timespan.Span, fromOther bool, differenceSpan timespan.Span) timespan.Span {
+		sides = append(sides, fromOther)
+		p := source.(*PropertyEvent)
+		return NewPropertyEvent(differenceSpan.Start(), differenceSpan.End(), p.Properties)
+	})
+
+	expectEqual(t, sides, []bool{false, true, true})
+	expectEqual(t, after, timespan.Spans{
+		NewPropertyEvent(now, now.Add(time.Hour), []string{"operator"}),
+		NewPropertyEvent(now.Add(2*time.Hour), now.Add(3*time.Hour), []string{"sensor"}),
+		NewPropertyEvent(now.Add(4*time.Hour), now.Add(5*time.Hour), []string{"sensor"}),
+	})
+}
@@ -88,3 +88,28 @@ func ExampleSpans_Intersection() {
 	// 2018-01-30 00:30:00 +0000 UTC -> 2018-01-30 01:00:00 +0000 UTC :  30m0s
 	// 2018-01-30 01:33:00 +0000 UTC -> 2018-01-30 01:34:00 +0000 UTC :  1m0s
 }
+
+func ExampleSpans_Difference() {
+	operating := spaniel.Spans{spaniel.New(times[0].from, times[1].to)}
+	maintenance := spaniel.Spans{spaniel.New(times[0].to, times[2].from)}
+	difference := operating.Difference(maintenance)
+
+	for d := range difference {
+		fmt.Println(difference[d].Start(), "->", difference[d].End(), ": ", difference[d].End().Sub(difference[d].Start()))
+	}
+
+	// Output:
+	// 2018-01-30 00:00:00 +0000 UTC -> 2018-01-30 01:00:00 +0000 UTC :  1h0m0s
+}
+
+func ExampleSpans_Complement() {
+	window := spaniel.New(times[0].from, times[3].to)
+	gaps := spaniel.Spans{spaniel.New(times[0].from, times[1].to)}.Complement(window)
+
+	for g := range gaps {
+		fmt.Println(gaps[g].Start(), "->", gaps[g].End(), ": ", gaps[g].End().Sub(gaps[g].Start()))
+	}
+
+	// Output:
+	// 2018-01-30 01:30:00 +0000 UTC -> 2018-01-30 01:34:00 +0000 UTC :  4m0s
+}
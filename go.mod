@@ -1,3 +1,3 @@
 module github.com/senseyeio/spaniel
 
-go 1.12
+go 1.23
@@ -0,0 +1,249 @@
+package spaniel
+
+import (
+	"slices"
+	"time"
+)
+
+// Index holds a set of spans in an interval tree, so that the spans overlapping a given span or time can be found
+// without comparing against every span in the set. Spans are held in the order given by Normalize, and queries
+// return them in that order. The zero value is an empty index, ready to use. An Index is not safe for concurrent use
+// if any goroutine modifies it.
+type Index struct {
+	root *indexNode
+	size int
+}
+
+type indexNode struct {
+	span        Span
+	left, right *indexNode
+	height      int
+	// The span with the latest end point in the subtree rooted at this node, used to skip subtrees which finish
+	// before a query starts.
+	latest Span
+}
+
+// NewIndex creates an Index holding the given spans.
+func NewIndex(spans Spans) *Index {
+	var sorted Spans
+	sorted = append(sorted, spans...)
+	slices.SortStableFunc(sorted, timeDomain.CompareSpans)
+	return &Index{root: buildIndex(sorted), size: len(sorted)}
+}
+
+// Builds a balanced tree from a sorted list of spans.
+func buildIndex(sorted Spans) *indexNode {
+	if len(sorted) == 0 {
+		return nil
+	}
+	middle := len(sorted) / 2
+	n := &indexNode{
+		span:  sorted[middle],
+		left:  buildIndex(sorted[:middle]),
+		right: buildIndex(sorted[middle+1:]),
+	}
+	n.update()
+	return n
+}
+
+// Len returns the number of spans held in the index.
+func (x *Index) Len() int {
+	return x.size
+}
+
+// Insert adds a span to the index.
+func (x *Index) Insert(s Span) {
+	x.root = x.root.insert(s)
+	x.size++
+}
+
+// Delete removes a span from the index, returning false if it wasn't found. The span is identified by comparing it
+// with the spans held using ==, so it must be the same value that was inserted, and must be of a comparable type,
+// such as a pointer to a struct.
+func (x *Index) Delete(s Span) bool {
+	var deleted bool
+	x.root, deleted = x.root.delete(s)
+	if deleted {
+		x.size--
+	}
+	return deleted
+}
+
+// Overlapping returns the list of spans in the index which overlap the given span.
+func (x *Index) Overlapping(s Span) Spans {
+	return x.root.search(s, func(held Span) bool {
+		return overlap(held, s)
+	}, Spans{})
+}
+
+// Returns the list of spans in the index which either overlap or are contiguous with the given span.
+func (x *Index) touching(s Span) Spans {
+	return x.root.search(s, func(held Span) bool {
+		return overlap(held, s) || contiguous(held, s)
+	}, Spans{})
+}
+
+// At returns the list of spans in the index which include the time t.
+func (x *Index) At(t time.Time) Spans {
+	return x.Overlapping(NewInstant(t))
+}
+
+// Spans returns the list of all of the spans in the index.
+func (x *Index) Spans() Spans {
+	return x.root.all(Spans{})
+}
+
+func (n *indexNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// Recalculates the height and latest span of the node from those of its children.
+func (n *indexNode) update() {
+	n.height = 1
+	n.latest = n.span
+	for _, child := range []*indexNode{n.left, n.right} {
+		if child == nil {
+			continue
+		}
+		if child.height >= n.height {
+			n.height = child.height + 1
+		}
+		if compareEnds(child.latest, n.latest) > 0 {
+			n.latest = child.latest
+		}
+	}
+}
+
+func (n *indexNode) rotateLeft() *indexNode {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *indexNode) rotateRight() *indexNode {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// Restores the balance of the subtree rooted at the node, after one of its children has changed height by at most one.
+func (n *indexNode) balance() *indexNode {
+	n.update()
+	switch n.left.getHeight() - n.right.getHeight() {
+	case 2:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case -2:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *indexNode) insert(s Span) *indexNode {
+	if n == nil {
+		return &indexNode{span: s, height: 1, latest: s}
+	}
+	if lessPoints(s, n.span) {
+		n.left = n.left.insert(s)
+	} else {
+		n.right = n.right.insert(s)
+	}
+	return n.balance()
+}
+
+func (n *indexNode) delete(s Span) (*indexNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch {
+	case lessPoints(s, n.span):
+		n.left, deleted = n.left.delete(s)
+	case lessPoints(n.span, s):
+		n.right, deleted = n.right.delete(s)
+	case n.span == s:
+		return n.deleteSelf(), true
+	default:
+		// Spans with the same end points may have been rotated to either side.
+		n.left, deleted = n.left.delete(s)
+		if !deleted {
+			n.right, deleted = n.right.delete(s)
+		}
+	}
+
+	if !deleted {
+		return n, false
+	}
+	return n.balance(), true
+}
+
+// Removes the node from the subtree rooted at it, returning the new root of the subtree.
+func (n *indexNode) deleteSelf() *indexNode {
+	if n.left == nil {
+		return n.right
+	}
+	if n.right == nil {
+		return n.left
+	}
+
+	// Replace the node's span with the first span to the right of it, which keeps the tree in order.
+	var first *indexNode
+	n.right, first = n.right.deleteFirst()
+	n.span = first.span
+	return n.balance()
+}
+
+// Removes the first node from the subtree rooted at the node, returning the new root of the subtree and the node removed.
+func (n *indexNode) deleteFirst() (*indexNode, *indexNode) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var first *indexNode
+	n.left, first = n.left.deleteFirst()
+	return n.balance(), first
+}
+
+// Appends the spans in the subtree rooted at the node for which match returns true to found. Only the spans which
+// have some time in common with s, ignoring the types of their end points, are passed to match.
+func (n *indexNode) search(s Span, match func(Span) bool, found Spans) Spans {
+	// Nothing in this subtree finishes late enough to reach the span.
+	if n == nil || n.latest.End().Before(s.Start()) {
+		return found
+	}
+
+	found = n.left.search(s, match, found)
+	if match(n.span) {
+		found = append(found, n.span)
+	}
+
+	// Everything to the right starts no earlier than this node, so if this node starts after the span finishes,
+	// nothing to the right can reach it.
+	if !n.span.Start().After(s.End()) {
+		found = n.right.search(s, match, found)
+	}
+	return found
+}
+
+func (n *indexNode) all(found Spans) Spans {
+	if n == nil {
+		return found
+	}
+	found = n.left.all(found)
+	found = append(found, n.span)
+	return n.right.all(found)
+}
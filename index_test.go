@@ -0,0 +1,110 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+// Returns the spans which overlap s by comparing against every span, for checking the results of an index.
+func overlappingSpans(spans timespan.Spans, s timespan.Span) timespan.Spans {
+	found := timespan.Spans{}
+	for _, span := range spans {
+		if timespan.Overlaps(span, s) {
+			found = append(found, span)
+		}
+	}
+	return found.Normalize()
+}
+
+func randomSpans(r *rand.Rand, n int) timespan.Spans {
+	types := []timespan.EndPointType{timespan.Open, timespan.Closed}
+	spans := timespan.Spans{}
+	for i := 0; i < n; i++ {
+		start := now.Add(time.Duration(r.Intn(1000)) * time.Minute)
+		if r.Intn(10) == 0 {
+			spans = append(spans, timespan.NewInstant(start))
+			continue
+		}
+		end := start.Add(time.Duration(1+r.Intn(60)) * time.Minute)
+		spans = append(spans, timespan.NewWithTypes(start, end, types[r.Intn(2)], types[r.Intn(2)]))
+	}
+	return spans
+}
+
+func TestIndex(t *testing.T) {
+	a := timespan.New(now, now.Add(time.Hour))
+	b := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+	c := timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour))
+	d := timespan.NewInstant(now.Add(2 * time.Hour))
+
+	t.Run("Should find nothing in an empty index", func(t *testing.T) {
+		var index timespan.Index
+		expectEqual(t, index.Len(), 0)
+		expectEqual(t, index.Overlapping(a), timespan.Spans{})
+		expectEqual(t, index.At(now), timespan.Spans{})
+	})
+
+	t.Run("Should find the spans overlapping a span", func(t *testing.T) {
+		index := timespan.NewIndex(timespan.Spans{c, b, a, d})
+		expectEqual(t, index.Len(), 4)
+		expectEqual(t, index.Overlapping(timespan.New(now.Add(45*time.Minute), now.Add(2*time.Hour))), timespan.Spans{a, b})
+		expectEqual(t, index.Overlapping(timespan.New(now.Add(45*time.Minute), now.Add(3*time.Hour))), timespan.Spans{a, b, d})
+		expectEqual(t, index.Overlapping(timespan.New(now.Add(5*time.Hour), now.Add(6*time.Hour))), timespan.Spans{})
+	})
+
+	t.Run("Should find the spans at a time", func(t *testing.T) {
+		index := timespan.NewIndex(timespan.Spans{c, b, a, d})
+		expectEqual(t, index.At(now.Add(45*time.Minute)), timespan.Spans{a, b})
+		expectEqual(t, index.At(now.Add(time.Hour)), timespan.Spans{b})
+		expectEqual(t, index.At(now.Add(2*time.Hour)), timespan.Spans{d})
+		expectEqual(t, index.At(now.Add(150*time.Minute)), timespan.Spans{})
+	})
+
+	t.Run("Should insert and delete spans", func(t *testing.T) {
+		var index timespan.Index
+		index.Insert(a)
+		index.Insert(b)
+		index.Insert(c)
+		expectEqual(t, index.Spans(), timespan.Spans{a, b, c})
+
+		expectEqual(t, index.Delete(b), true)
+		expectEqual(t, index.Delete(b), false)
+		expectEqual(t, index.Len(), 2)
+		expectEqual(t, index.Spans(), timespan.Spans{a, c})
+		expectEqual(t, index.At(now.Add(90*time.Minute)), timespan.Spans{})
+	})
+
+	t.Run("Should only delete the given span when others are equal to it", func(t *testing.T) {
+		e := NewPropertyEvent(now, now.Add(time.Hour), []string{"e"})
+		f := NewPropertyEvent(now, now.Add(time.Hour), []string{"f"})
+		index := timespan.NewIndex(timespan.Spans{e, f})
+		expectEqual(t, index.Delete(timespan.New(now, now.Add(time.Hour))), false)
+		expectEqual(t, index.Delete(f), true)
+		expectEqual(t, index.Spans(), timespan.Spans{e})
+	})
+
+	t.Run("Should match the overlaps found by comparing every span", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		spans := randomSpans(r, 500)
+
+		index := timespan.NewIndex(spans[:250])
+		for _, span := range spans[250:] {
+			index.Insert(span)
+		}
+		for _, span := range spans[:100] {
+			if !index.Delete(span) {
+				t.Fatalf("expected %v to be deleted", span)
+			}
+		}
+		remaining := spans[100:]
+		expectEqual(t, index.Len(), len(remaining))
+		expectEqual(t, index.Spans(), remaining.Normalize())
+
+		for _, query := range randomSpans(r, 200) {
+			expectEqual(t, index.Overlapping(query), overlappingSpans(remaining, query))
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package spaniel
+
+import (
+	"slices"
+	"sort"
+)
+
+// UnionInPlace returns the same list of Spans as Union, sorting and merging the spans within s rather than copying
+// them, so that the returned list shares its storage with s. The spans in s are rearranged, and those after the end
+// of the returned list are set to nil. Only one TimeSpan is allocated for each merged span, however many spans are
+// merged into it, and spans which aren't merged with any other are returned as they are.
+func (s Spans) UnionInPlace() Spans {
+	if len(s) < 2 {
+		return s
+	}
+	sort.Stable(ByStart(s))
+
+	// The merged spans are written to the start of s, each at or before the span being read. A merged span is only
+	// changed in place if it was created here, never if it is one of the spans given.
+	last, created := 0, false
+	for i := 1; i < len(s); i++ {
+		a, b := s[last], s[i]
+		if !overlap(a, b) && !contiguous(a, b) {
+			last++
+			s[last], created = b, false
+			continue
+		}
+
+		start, end := timeDomain.MergePoints(a, b)
+		if created {
+			*s[last].(*TimeSpan) = TimeSpan{start.Element, end.Element, start.Type, end.Type}
+			continue
+		}
+		s[last], created = NewWithTypes(start.Element, end.Element, start.Type, end.Type), true
+	}
+
+	clear(s[last+1:])
+	return s[:last+1]
+}
+
+// UnionTimeSpans returns the union of a list of TimeSpans, in the same way as Union, without allocating any memory.
+// The spans are sorted and merged within s, and the returned list shares its storage with s.
+func UnionTimeSpans(s []TimeSpan) []TimeSpan {
+	if len(s) < 2 {
+		return s
+	}
+	slices.SortStableFunc(s, func(a, b TimeSpan) int {
+		return a.start.Compare(b.start)
+	})
+
+	last := 0
+	for i := 1; i < len(s); i++ {
+		// Pointers to the spans are used as Spans, so that they don't need to be copied onto the heap.
+		a, b := &s[last], &s[i]
+		if !overlap(a, b) && !contiguous(a, b) {
+			last++
+			s[last] = *b
+			continue
+		}
+
+		start, end := timeDomain.MergePoints(a, b)
+		s[last] = TimeSpan{start.Element, end.Element, start.Type, end.Type}
+	}
+	return s[:last+1]
+}
@@ -0,0 +1,111 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"testing"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+// Returns copies of the spans as TimeSpans.
+func timeSpans(spans timespan.Spans) []timespan.TimeSpan {
+	var values []timespan.TimeSpan
+	for _, span := range spans {
+		values = append(values, *timespan.NewWithTypes(span.Start(), span.End(), span.StartType(), span.EndType()))
+	}
+	return values
+}
+
+func TestUnionInPlace(t *testing.T) {
+	spans := randomSpans(rand.New(rand.NewSource(1)), 500)
+
+	t.Run("Should return the same spans as Union", func(t *testing.T) {
+		expected := spans.Union()
+		input := append(timespan.Spans{}, spans...)
+		expectEqual(t, input.UnionInPlace(), expected)
+	})
+
+	t.Run("Should not modify the spans given", func(t *testing.T) {
+		before := timeSpans(spans)
+		input := append(timespan.Spans{}, spans...)
+		input.UnionInPlace()
+		expectEqual(t, timeSpans(spans), before)
+	})
+
+	t.Run("Should share storage with the list given", func(t *testing.T) {
+		input := append(timespan.Spans{}, spans...)
+		after := input.UnionInPlace()
+		expectEqual(t, &after[0] == &input[0], true)
+		expectEqual(t, input[len(after)], nil)
+	})
+
+	t.Run("Should allocate one span for each merged span", func(t *testing.T) {
+		input := make(timespan.Spans, len(spans))
+		merged := 0
+		for _, span := range spans.Union() {
+			if !contains(spans, span) {
+				merged++
+			}
+		}
+		allocs := testing.AllocsPerRun(10, func() {
+			copy(input, spans)
+			input.UnionInPlace()
+		})
+		// Sorting the list as a sort.Interface allocates once.
+		expectEqual(t, int(allocs), merged+1)
+	})
+}
+
+func contains(spans timespan.Spans, span timespan.Span) bool {
+	for _, s := range spans {
+		if s == span {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUnionTimeSpans(t *testing.T) {
+	spans := randomSpans(rand.New(rand.NewSource(1)), 500)
+
+	t.Run("Should return the same spans as Union", func(t *testing.T) {
+		expectEqual(t, timespan.UnionTimeSpans(timeSpans(spans)), timeSpans(spans.Union()))
+	})
+
+	t.Run("Should handle short lists", func(t *testing.T) {
+		expectEqual(t, timespan.UnionTimeSpans(nil), []timespan.TimeSpan(nil))
+		expectEqual(t, timespan.UnionTimeSpans(timeSpans(spans[:1])), timeSpans(spans[:1]))
+	})
+
+	t.Run("Should not allocate", func(t *testing.T) {
+		values := timeSpans(spans)
+		input := make([]timespan.TimeSpan, len(values))
+		allocs := testing.AllocsPerRun(10, func() {
+			copy(input, values)
+			timespan.UnionTimeSpans(input)
+		})
+		expectEqual(t, allocs, float64(0))
+	})
+}
+
+func BenchmarkUnionInPlace(b *testing.B) {
+	spans := randomSpans(rand.New(rand.NewSource(1)), 100000)
+	input := make(timespan.Spans, len(spans))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(input, spans)
+		input.UnionInPlace()
+	}
+}
+
+func BenchmarkUnionTimeSpans(b *testing.B) {
+	values := timeSpans(randomSpans(rand.New(rand.NewSource(1)), 100000))
+	input := make([]timespan.TimeSpan, len(values))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(input, values)
+		timespan.UnionTimeSpans(input)
+	}
+}
@@ -1,33 +1,26 @@
 package spaniel
 
 import (
-	"sort"
 	"time"
+
+	"github.com/senseyeio/spaniel/interval"
 )
 
 // EndPointType represents whether the start or end of an interval is Closed or Open.
-type EndPointType int
+type EndPointType = interval.EndPointType
 
 const (
 	// Open means that the interval does not include a value
-	Open EndPointType = iota
+	Open = interval.Open
 	// Closed means that the interval does include a value
-	Closed
+	Closed = interval.Closed
 )
 
 // EndPoint represents an extreme of an interval, and whether it is inclusive or exclusive (Closed or Open)
-type EndPoint struct {
-	Element time.Time
-	Type    EndPointType
-}
+type EndPoint = interval.EndPoint[time.Time]
 
 // Span represents a basic span, with a start and end time.
-type Span interface {
-	Start() time.Time
-	StartType() EndPointType
-	End() time.Time
-	EndType() EndPointType
-}
+type Span = interval.Span[time.Time]
 
 // Spans represents a list of spans, on which other functions operate.
 type Spans []Span
@@ -54,189 +47,84 @@ type UnionHandlerFunc func(mergeInto, mergeFrom, mergeSpan Span) Span
 // intersect. It is passed the two spans that intersect, and span representing the intersection.
 type IntersectionHandlerFunc func(intersectingEvent1, intersectingEvent2, intersectionSpan Span) Span
 
-func getLoosestIntervalType(x, y EndPointType) EndPointType {
-	if x > y {
-		return x
-	}
-	return y
+// The Domain implementing the functions of this package, which orders spans by time and creates TimeSpans.
+var timeDomain = interval.Domain[time.Time]{
+	Compare: time.Time.Compare,
+	New: func(start, end time.Time, startType, endType EndPointType) Span {
+		return NewWithTypes(start, end, startType, endType)
+	},
 }
 
-func getTightestIntervalType(x, y EndPointType) EndPointType {
-	if x < y {
-		return x
-	}
-	return y
-}
+// The list type operated on by timeDomain, to which Spans can be converted.
+type spans = interval.Spans[time.Time]
 
-func getMin(a, b EndPoint) EndPoint {
-	if a.Element.Before(b.Element) {
-		return a
-	}
-	return b
+// Returns -1, 0 or +1 depending on whether the end of a comes before, at the same point as, or after the end of b.
+func compareEnds(a, b Span) int {
+	return timeDomain.CompareEnds(a, b)
 }
 
-func getMax(a, b EndPoint) EndPoint {
-	if a.Element.After(b.Element) {
-		return a
-	}
-	return b
-}
-
-func filter(spans Spans, filterFunc func(Span) bool) Spans {
-	filtered := Spans{}
-	for _, span := range spans {
-		if !filterFunc(span) {
-			filtered = append(filtered, span)
-		}
-	}
-	return filtered
-}
-
-// IsInstant returns true if the interval is deemed instantaneous
+// IsInstant returns true if the interval is deemed instantaneous. Any span which starts and ends at the same time is
+// treated as a Closed instant, whatever its types; see IsEmpty.
 func IsInstant(a Span) bool {
-	return a.Start().Equal(a.End())
+	return timeDomain.IsInstant(a)
 }
 
 // Returns true if two spans are side by side
 func contiguous(a, b Span) bool {
-	// [1,2,3,4] [4,5,6,7] - not contiguous
-	// [1,2,3,4) [4,5,6,7] - contiguous
-	// [1,2,3,4] (4,5,6,7] - contiguous
-	// [1,2,3,4) (4,5,6,7] - not contiguous
-	// [1,2,3] [5,6,7] - not contiguous
-	// [1] (1,2,3] - contiguous
-
-	// Two instants can't be contiguous
-	if IsInstant(a) && IsInstant(b) {
-		return false
-	}
-
-	if b.Start().Before(a.Start()) {
-		a, b = b, a
-	}
-
-	aStartType := a.StartType()
-	aEndType := a.EndType()
-	bStartType := b.StartType()
-
-	if IsInstant(a) {
-		aEndType = Closed
-		aStartType = Closed
-	}
-	if IsInstant(b) {
-		bStartType = Closed
-	}
-
-	// If a and b start at the same point, just check that their start types are different.
-	if a.Start().Equal(b.Start()) {
-		return aStartType != bStartType
-	}
-
-	// To be contiguous the ranges have to overlap on the first/last point
-	if !(a.End().Equal(b.Start())) {
-		return false
-	}
-
-	if aEndType == bStartType {
-		return false
-	}
-	return true
+	return timeDomain.Contiguous(a, b)
 }
 
 // Returns true if two spans overlap
 func overlap(a, b Span) bool {
-	// [1,2,3,4] [4,5,6,7] - intersects
-	// [1,2,3,4) [4,5,6,7] - doesn't intersect
-	// [1,2,3,4] (4,5,6,7] - doesn't intersect
-	// [1,2,3,4) (4,5,6,7] - doesn't intersect
-
-	aStartType := a.StartType()
-	aEndType := a.EndType()
-	bStartType := b.StartType()
-	bEndType := b.EndType()
-
-	if IsInstant(a) {
-		aStartType = Closed
-		aEndType = Closed
-	}
-	if IsInstant(b) {
-		bStartType = Closed
-		bEndType = Closed
-	}
+	return timeDomain.Overlaps(a, b)
+}
 
-	// Given [a_s,a_e] and [b_s,b_e]
-	// If a_s > b_e || a_e < b_s, overlap == false
+// Overlaps returns true if there is any time covered by both a and b, taking the types of their end points into
+// account. For example, [1,2] and [2,3] overlap, but [1,2) and [2,3] don't.
+func Overlaps(a, b Span) bool {
+	return overlap(a, b)
+}
 
-	c1 := false // is a_s after b_e
-	if a.Start().After(b.End()) {
-		c1 = true
-	} else if a.Start().Equal(b.End()) {
-		c1 = (aStartType == Open || bEndType == Open)
-	}
+// Contiguous returns true if a and b are side by side, so that together they cover a continuous span of time without
+// overlapping. For example, [1,2) and [2,3] are contiguous, but [1,2] and [2,3] (which overlap) and [1,2) and (2,3]
+// (which leave a gap) are not.
+func Contiguous(a, b Span) bool {
+	return contiguous(a, b)
+}
 
-	c2 := false // is a_e before b_s
-	if a.End().Before(b.Start()) {
-		c2 = true
-	} else if a.End().Equal(b.Start()) {
-		c2 = (aEndType == Open || bStartType == Open)
-	}
+// ContainsTime returns true if the time t lies within s, taking the types of its end points into account.
+func ContainsTime(s Span, t time.Time) bool {
+	return timeDomain.Contains(s, t)
+}
 
-	if c1 || c2 {
-		return false
-	}
+// ContainsSpan returns true if all of the time covered by inner is also covered by outer.
+func ContainsSpan(outer, inner Span) bool {
+	return timeDomain.ContainsSpan(outer, inner)
+}
+
+// Equal returns true if a and b cover exactly the same time, with the same types of end points. Instants are
+// treated as Closed at both ends, as they are elsewhere.
+func Equal(a, b Span) bool {
+	return timeDomain.Equal(a, b)
+}
 
-	return true
+// Returns the span covering both a and b, which must overlap or be contiguous.
+func merge(a, b Span) Span {
+	return timeDomain.Merge(a, b)
 }
 
 // UnionWithHandler returns a list of Spans representing the union of all of the spans.
 // For example, given a list [A,B] where A and B overlap, a list [C] would be returned, with the span C spanning
 // both A and B. The provided handler is passed the source and destination spans, and the currently merged empty span.
 func (s Spans) UnionWithHandler(unionHandlerFunc UnionHandlerFunc) Spans {
-
-	if len(s) < 2 {
-		return s
-	}
-
-	var sorted Spans
-	sorted = append(sorted, s...)
-	sort.Stable(ByStart(sorted))
-
-	result := Spans{sorted[0]}
-
-	for _, b := range sorted[1:] {
-		// A: current span in merged array; B: current span in sorted array
-		// If B overlaps with A, it can be merged with A.
-		a := result[len(result)-1]
-		if overlap(a, b) || contiguous(a, b) {
-
-			spanStart := getMin(EndPoint{a.Start(), a.StartType()}, EndPoint{b.Start(), b.StartType()})
-			spanEnd := getMax(EndPoint{a.End(), a.EndType()}, EndPoint{b.End(), b.EndType()})
-
-			if a.Start().Equal(b.Start()) {
-				spanStart.Type = getLoosestIntervalType(a.StartType(), b.StartType())
-			}
-			if a.End().Equal(b.End()) {
-				spanEnd.Type = getLoosestIntervalType(a.EndType(), b.EndType())
-			}
-
-			span := NewWithTypes(spanStart.Element, spanEnd.Element, spanStart.Type, spanEnd.Type)
-			result[len(result)-1] = unionHandlerFunc(a, b, span)
-
-			continue
-		}
-		result = append(result, b)
-	}
-
-	return result
+	return Spans(timeDomain.UnionWithHandler(spans(s), interval.UnionHandlerFunc[time.Time](unionHandlerFunc)))
 }
 
 // Union returns a list of Spans representing the union of all of the spans.
 // For example, given a list [A,B] where A and B overlap, a list [C] would be returned, with the span C spanning
 // both A and B.
 func (s Spans) Union() Spans {
-	return s.UnionWithHandler(func(mergeInto, mergeFrom, mergeSpan Span) Span {
-		return mergeSpan
-	})
+	return Spans(timeDomain.Union(spans(s)))
 }
 
 // IntersectionWithHandler returns a list of Spans representing the overlaps between the contained spans.
@@ -244,78 +132,55 @@ func (s Spans) Union() Spans {
 // the intersection of the A and B. The provided handler function is notified of the two spans that have been found
 // to overlap, and the span representing the overlap.
 func (s Spans) IntersectionWithHandler(intersectHandlerFunc IntersectionHandlerFunc) Spans {
-	var sorted Spans
-	sorted = append(sorted, s...)
-	sort.Stable(ByStart(sorted))
-
-	actives := Spans{sorted[0]}
-
-	intersections := Spans{}
-
-	for _, b := range sorted[1:] {
-		// Tidy up the active span list
-		actives = filter(actives, func(t Span) bool {
-			// If this value is identical to one in actives, don't filter it.
-			if b.Start() == t.Start() && b.End() == t.End() {
-				return false
-			}
-			// If this value starts after the one in actives finishes, filter the active.
-			return b.Start().After(t.End())
-		})
-
-		for _, a := range actives {
-			if overlap(a, b) {
-				spanStart := getMax(EndPoint{a.Start(), a.StartType()}, EndPoint{b.Start(), b.StartType()})
-				spanEnd := getMin(EndPoint{a.End(), a.EndType()}, EndPoint{b.End(), b.EndType()})
-
-				if a.Start().Equal(b.Start()) {
-					spanStart.Type = getTightestIntervalType(a.StartType(), b.StartType())
-				}
-				if a.End().Equal(b.End()) {
-					spanEnd.Type = getTightestIntervalType(a.EndType(), b.EndType())
-				}
-				span := NewWithTypes(spanStart.Element, spanEnd.Element, spanStart.Type, spanEnd.Type)
-				intersection := intersectHandlerFunc(a, b, span)
-				intersections = append(intersections, intersection)
-			}
-		}
-		actives = append(actives, b)
-	}
-	return intersections
+	return Spans(timeDomain.IntersectionWithHandler(spans(s), interval.IntersectionHandlerFunc[time.Time](intersectHandlerFunc)))
 }
 
 // Intersection returns a list of Spans representing the overlaps between the contained spans.
 // For example, given a list [A,B] where A and B overlap, a list [C] would be returned,
 // with the span C covering the intersection of A and B.
 func (s Spans) Intersection() Spans {
-	return s.IntersectionWithHandler(func(intersectingEvent1, intersectingEvent2, intersectionSpan Span) Span {
-		return intersectionSpan
-	})
+	return Spans(timeDomain.Intersection(spans(s)))
 }
 
 // IntersectionBetweenWithHandler returns a list of pointers to Spans representing the overlaps between the contained spans
 // and a given set of spans. It calls intersectHandlerFunc for each pair of spans that are intersected.
 func (s Spans) IntersectionBetweenWithHandler(candidates Spans, intersectHandlerFunc IntersectionHandlerFunc) Spans {
-	intersections := Spans{}
-	for _, candidate := range candidates {
-		for _, span := range s {
-			i := Spans{candidate, span}.IntersectionWithHandler(func(a, b, s Span) Span {
-				if a == candidate {
-					return intersectHandlerFunc(a, b, s)
-				}
-
-				return intersectHandlerFunc(b, a, s)
-			})
-			intersections = append(intersections, i...)
-		}
-	}
-	return intersections
+	return Spans(timeDomain.IntersectionBetweenWithHandler(spans(s), spans(candidates), interval.IntersectionHandlerFunc[time.Time](intersectHandlerFunc)))
 }
 
 // IntersectionBetween returns the slice of spans representing the overlaps between the contained spans
 // and a given set of spans.
 func (s Spans) IntersectionBetween(b Spans) Spans {
-	return s.IntersectionBetweenWithHandler(b, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span) Span {
-		return intersectionSpan
-	})
+	return Spans(timeDomain.IntersectionBetween(spans(s), spans(b)))
+}
+
+// IntersectAllHandlerFunc is used by IntersectAllWithHandler to allow for custom functionality when time is found to
+// be covered by every list. It is passed the span from each list which covers the intersection, in the order the
+// lists were given, and the span representing the intersection.
+type IntersectAllHandlerFunc func(intersectingSpans Spans, intersectionSpan Span) Span
+
+// IntersectAllWithHandler returns a list of Spans representing the time covered by every one of the given lists.
+// For example, given lists [A], [B] and [C] where A, B and C all overlap, a list [D] would be returned, with the span D
+// covering the time common to A, B and C. Each list is merged as by Union first, so that the handler is passed the
+// merged span from each list which covers the intersection, along with the span representing the intersection.
+func IntersectAllWithHandler(intersectAllHandlerFunc IntersectAllHandlerFunc, lists ...Spans) Spans {
+	return Spans(timeDomain.IntersectAllWithHandler(func(intersectingSpans spans, intersectionSpan Span) Span {
+		return intersectAllHandlerFunc(Spans(intersectingSpans), intersectionSpan)
+	}, genericLists(lists)...))
+}
+
+// IntersectAll returns a list of Spans representing the time covered by every one of the given lists.
+// For example, given lists [A], [B] and [C] where A, B and C all overlap, a list [D] would be returned, with the span D
+// covering the time common to A, B and C.
+func IntersectAll(lists ...Spans) Spans {
+	return Spans(timeDomain.IntersectAll(genericLists(lists)...))
+}
+
+// Converts a list of Spans to the list type operated on by timeDomain.
+func genericLists(lists []Spans) []spans {
+	converted := make([]spans, len(lists))
+	for i, list := range lists {
+		converted[i] = spans(list)
+	}
+	return converted
 }
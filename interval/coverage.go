@@ -0,0 +1,95 @@
+package interval
+
+import (
+	"slices"
+	"sort"
+)
+
+// CoverageSpan represents a span of values throughout which the same number of spans are active.
+type CoverageSpan[T any] struct {
+	Span[T]
+	// Count is the number of spans which cover the whole of the span.
+	Count int
+}
+
+// Coverage partitions the values covered by the spans into a list of CoverageSpans, each annotated with the number of
+// spans active throughout it.
+// For example, given a list [A,B] where A and B overlap, a list [C,D,E] would be returned, where C covers A up to the
+// start of B with a count of 1, D covers the intersection of A and B with a count of 2, and E covers B from the end of
+// A with a count of 1. The returned spans are sorted, don't overlap one another, and honour the endpoint types of the
+// spans they were derived from, so that spans touching at a single Closed point result in an instant with a count
+// of 2. Values which are not covered by any span are omitted.
+func (d Domain[T]) Coverage(s Spans[T]) []CoverageSpan[T] {
+	var values []T
+	for _, span := range s {
+		values = append(values, span.Start(), span.End())
+	}
+	slices.SortFunc(values, d.Compare)
+
+	// Remove duplicated values, so that each boundary appears just once.
+	values = slices.CompactFunc(values, d.equal)
+
+	index := func(v T) int {
+		return sort.Search(len(values), func(i int) bool { return !d.before(values[i], v) })
+	}
+
+	// The values are divided into positions, alternating between each boundary and the gap between it and the next
+	// boundary, so that position 2k is values[k], and position 2k+1 is (values[k],values[k+1]). A change in the
+	// number of active spans is then recorded at the first position each span covers, and the position after the last.
+	changes := make([]int, 2*len(values))
+	for _, span := range s {
+		start, end := d.startPoint(span), d.endPoint(span)
+		from, to := 2*index(start.Element), 2*index(end.Element)
+		if start.Type == Open {
+			from++
+		}
+		if end.Type == Open {
+			to--
+		}
+		if from > to {
+			continue
+		}
+		changes[from]++
+		changes[to+1]--
+	}
+
+	position := func(p int, start bool) EndPoint[T] {
+		if p%2 == 0 {
+			return EndPoint[T]{values[p/2], Closed}
+		}
+		if start {
+			return EndPoint[T]{values[p/2], Open}
+		}
+		return EndPoint[T]{values[p/2+1], Open}
+	}
+
+	var coverage []CoverageSpan[T]
+	count := 0
+	for p := 0; p < len(changes)-1; {
+		count += changes[p]
+		q := p
+		for q+1 < len(changes)-1 && changes[q+1] == 0 {
+			q++
+		}
+		if count > 0 {
+			from, to := position(p, true), position(q, false)
+			span := d.New(from.Element, to.Element, from.Type, to.Type)
+			coverage = append(coverage, CoverageSpan[T]{span, count})
+		}
+		p = q + 1
+	}
+	return coverage
+}
+
+// CoveredBy returns a list of Spans representing the values covered by at least n of the spans.
+// For example, given a list [A,B,C] where A and B overlap, but C overlaps neither, a call with n of 2 would return a
+// list [D], with the span D covering the intersection of A and B.
+func (d Domain[T]) CoveredBy(s Spans[T], n int) Spans[T] {
+	covered := Spans[T]{}
+	for _, c := range d.Coverage(s) {
+		if c.Count >= n {
+			covered = append(covered, c.Span)
+		}
+	}
+	return d.Union(covered)
+}
@@ -0,0 +1,153 @@
+package interval
+
+// DifferenceHandlerFunc is used by DifferenceWithHandler to allow for custom functionality when part of a span is
+// removed. It is passed the span which has been cut, and the span representing the fragment that remains.
+type DifferenceHandlerFunc[T any] func(subtractFrom, differenceSpan Span[T]) Span[T]
+
+// Returns true if the points from and to bound at least one value.
+func (d Domain[T]) nonEmpty(from, to EndPoint[T]) bool {
+	if d.equal(from.Element, to.Element) {
+		return getTightestIntervalType(from.Type, to.Type) == Closed
+	}
+	return d.before(from.Element, to.Element)
+}
+
+// Calls emit with each fragment of a which is not covered by removals. The removals must be sorted and must not
+// overlap one another, as returned by Union. It returns false if none of the removals overlap a.
+func (d Domain[T]) subtract(a Span[T], removals Spans[T], emit func(from, to EndPoint[T])) bool {
+	start := d.startPoint(a)
+	cut := false
+	for _, r := range removals {
+		if d.after(r.Start(), a.End()) {
+			break
+		}
+		if !d.Overlaps(a, r) {
+			continue
+		}
+		cut = true
+
+		// The fragment before r ends where r starts, and includes that point only if r does not.
+		rStart, rEnd := d.startPoint(r), d.endPoint(r)
+		to := EndPoint[T]{rStart.Element, flip(rStart.Type)}
+		if d.nonEmpty(start, to) {
+			emit(start, to)
+		}
+		start = EndPoint[T]{rEnd.Element, flip(rEnd.Type)}
+	}
+	if !cut {
+		return false
+	}
+
+	if end := d.endPoint(a); d.nonEmpty(start, end) {
+		emit(start, end)
+	}
+	return true
+}
+
+// Calls emit with each of the sorted spans that none of the removals overlap, and with each fragment left over from
+// the spans that they do overlap, until emit returns false. The removals must be sorted and must not overlap one
+// another, as returned by Union.
+func (d Domain[T]) differenceOf(sorted, removals Spans[T], emit func(a, span Span[T], cut bool) bool) {
+	first := 0
+	for _, a := range sorted {
+		// Removals which finish before this span starts can't overlap it, or any of the spans following it.
+		for first < len(removals) && d.before(removals[first].End(), a.Start()) {
+			first++
+		}
+
+		ok := true
+		cut := d.subtract(a, removals[first:], func(from, to EndPoint[T]) {
+			if ok {
+				ok = emit(a, d.New(from.Element, to.Element, from.Type, to.Type), true)
+			}
+		})
+		if !cut {
+			ok = emit(a, a, false)
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// DifferenceWithHandler returns a list of Spans representing the values covered by the spans in s, but not by any of
+// the spans in other.
+// For example, given a list [A] and another list [B] where B lies within A, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers A from the end of B. The endpoint types of the fragments are taken from
+// the spans they were cut from, so subtracting [1,2) from [1,3] results in [2,3]. The provided handler is passed the
+// span being cut, and the span representing each of the fragments left over. Spans which do not overlap other are
+// returned unchanged.
+func (d Domain[T]) DifferenceWithHandler(s, other Spans[T], differenceHandlerFunc DifferenceHandlerFunc[T]) Spans[T] {
+	differences := Spans[T]{}
+	d.eachDifference(s, other, differenceHandlerFunc, func(difference Span[T]) bool {
+		differences = append(differences, difference)
+		return true
+	})
+	return differences
+}
+
+// Calls yield with each of the spans returned by DifferenceWithHandler, until yield returns false.
+func (d Domain[T]) eachDifference(s, other Spans[T], differenceHandlerFunc DifferenceHandlerFunc[T], yield func(Span[T]) bool) {
+	d.differenceOf(d.sortedByStart(s), d.Union(other), func(a, span Span[T], cut bool) bool {
+		if cut {
+			span = differenceHandlerFunc(a, span)
+		}
+		return yield(span)
+	})
+}
+
+// Difference returns a list of Spans representing the values covered by the spans in s, but not by any of the spans
+// in other.
+// For example, given a list [A] and another list [B] where B lies within A, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers A from the end of B.
+func (d Domain[T]) Difference(s, other Spans[T]) Spans[T] {
+	return d.DifferenceWithHandler(s, other, func(subtractFrom, differenceSpan Span[T]) Span[T] {
+		return differenceSpan
+	})
+}
+
+// Complement returns a list of Spans representing the gaps between the spans in s within the given window.
+// For example, given a list [A,B] where A and B are separate and both lie within the window, a list [C,D,E] would be
+// returned, covering the window before A, between A and B, and after B. The endpoint types of the gaps are the
+// opposite of the types of the spans they border, so a Closed end in the list results in an Open start of a gap.
+func (d Domain[T]) Complement(s Spans[T], window Span[T]) Spans[T] {
+	return d.Difference(Spans[T]{window}, s)
+}
+
+// SymmetricDifferenceHandlerFunc is used by SymmetricDifferenceWithHandler to allow for custom functionality when
+// values are found to be covered by only one of the two lists. It is passed the span the values were found in, whether
+// that span came from other rather than s, and the span representing the values covered by it alone.
+type SymmetricDifferenceHandlerFunc[T any] func(source Span[T], fromOther bool, differenceSpan Span[T]) Span[T]
+
+// SymmetricDifferenceWithHandler returns a list of Spans representing the values covered by either the spans in s or
+// the spans in other, but not by both.
+// For example, given a list [A] and another list [B] where A and B overlap, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers B from the end of A. Each list is merged as by Union before the values
+// covered by the other list are removed from it, so that the handler is passed the merged span each fragment was cut
+// from. Unlike DifferenceWithHandler, the handler is called for every span returned, including spans which the other
+// list does not overlap, so that the side each span came from is always known.
+func (d Domain[T]) SymmetricDifferenceWithHandler(s, other Spans[T], symmetricDifferenceHandlerFunc SymmetricDifferenceHandlerFunc[T]) Spans[T] {
+	left, right := d.Union(s), d.Union(other)
+
+	differences := Spans[T]{}
+	d.differenceOf(left, right, func(a, span Span[T], cut bool) bool {
+		differences = append(differences, symmetricDifferenceHandlerFunc(a, false, span))
+		return true
+	})
+	d.differenceOf(right, left, func(a, span Span[T], cut bool) bool {
+		differences = append(differences, symmetricDifferenceHandlerFunc(a, true, span))
+		return true
+	})
+	d.sortByStart(differences)
+	return differences
+}
+
+// SymmetricDifference returns a list of Spans representing the values covered by either the spans in s or the spans
+// in other, but not by both.
+// For example, given a list [A] and another list [B] where A and B overlap, a list [C,D] would be returned, where C
+// covers A up to the start of B, and D covers B from the end of A.
+func (d Domain[T]) SymmetricDifference(s, other Spans[T]) Spans[T] {
+	return d.SymmetricDifferenceWithHandler(s, other, func(source Span[T], fromOther bool, differenceSpan Span[T]) Span[T] {
+		return differenceSpan
+	})
+}
@@ -0,0 +1,22 @@
+package interval_test
+
+import (
+	"fmt"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+func ExampleOrdered() {
+	// Byte offsets of the parts of a file which have been downloaded
+	downloaded := interval.Spans[int64]{
+		interval.New[int64](0, 1024),
+		interval.New[int64](4096, 8192),
+		interval.New[int64](1024, 2048),
+	}
+
+	offsets := interval.Ordered[int64]()
+	fmt.Println(offsets.Union(downloaded))
+	fmt.Println(offsets.Complement(downloaded, interval.New[int64](0, 8192)))
+	// Output: [[0,2048) [4096,8192)]
+	// [[2048,4096)]
+}
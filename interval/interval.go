@@ -0,0 +1,567 @@
+// Package interval provides the operations of spaniel over spans of any ordered type, such as sample indices,
+// odometer readings or byte offsets, rather than just time.Time.
+//
+// The operations are methods of a Domain, which describes how the values of the type are ordered, and how the spans
+// returned are created. Ordered provides a Domain for any type which supports the < operator:
+//
+//	spans := interval.Spans[int]{interval.New(1, 5), interval.New(3, 8)}
+//	union := interval.Ordered[int]().Union(spans) // [1,8)
+package interval
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// EndPointType represents whether the start or end of an interval is Closed or Open.
+type EndPointType int
+
+const (
+	// Open means that the interval does not include a value
+	Open EndPointType = iota
+	// Closed means that the interval does include a value
+	Closed
+)
+
+// EndPoint represents an extreme of an interval, and whether it is inclusive or exclusive (Closed or Open)
+type EndPoint[T any] struct {
+	Element T
+	Type    EndPointType
+}
+
+// Span represents a basic span, with a start and end value.
+type Span[T any] interface {
+	Start() T
+	StartType() EndPointType
+	End() T
+	EndType() EndPointType
+}
+
+// Spans represents a list of spans, on which other functions operate.
+type Spans[T any] []Span[T]
+
+// UnionHandlerFunc is used by UnionWithHandler to allow for custom functionality when two spans are merged.
+// It is passed the two spans to be merged, and span which will result from the union.
+type UnionHandlerFunc[T any] func(mergeInto, mergeFrom, mergeSpan Span[T]) Span[T]
+
+// IntersectionHandlerFunc is used by IntersectionWithHandler to allow for custom functionality when two spans
+// intersect. It is passed the two spans that intersect, and span representing the intersection.
+type IntersectionHandlerFunc[T any] func(intersectingEvent1, intersectingEvent2, intersectionSpan Span[T]) Span[T]
+
+// Domain describes the values spans are made up of, and provides the operations on spans of those values.
+type Domain[T any] struct {
+	// Compare returns a negative number if a comes before b, a positive number if a comes after b, and zero if they
+	// are the same value.
+	Compare func(a, b T) int
+	// New creates the spans returned by the operations, such as the merged spans returned by Union.
+	New func(start, end T, startType, endType EndPointType) Span[T]
+}
+
+// Ordered returns a Domain for a type supporting the < operator, which returns the spans it creates as Ranges.
+func Ordered[T cmp.Ordered]() Domain[T] {
+	return Domain[T]{
+		Compare: cmp.Compare[T],
+		New: func(start, end T, startType, endType EndPointType) Span[T] {
+			return NewWithTypes(start, end, startType, endType)
+		},
+	}
+}
+
+func (d Domain[T]) before(a, b T) bool { return d.Compare(a, b) < 0 }
+func (d Domain[T]) after(a, b T) bool  { return d.Compare(a, b) > 0 }
+func (d Domain[T]) equal(a, b T) bool  { return d.Compare(a, b) == 0 }
+
+// Returns a copy of the spans, sorted by their start values, keeping spans which start together in their original
+// order.
+func (d Domain[T]) sortedByStart(s Spans[T]) Spans[T] {
+	var sorted Spans[T]
+	sorted = append(sorted, s...)
+	d.sortByStart(sorted)
+	return sorted
+}
+
+func (d Domain[T]) sortByStart(s Spans[T]) {
+	slices.SortStableFunc(s, func(a, b Span[T]) int { return d.Compare(a.Start(), b.Start()) })
+}
+
+func getLoosestIntervalType(x, y EndPointType) EndPointType {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func getTightestIntervalType(x, y EndPointType) EndPointType {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func (d Domain[T]) getMin(a, b EndPoint[T]) EndPoint[T] {
+	if d.before(a.Element, b.Element) {
+		return a
+	}
+	return b
+}
+
+func (d Domain[T]) getMax(a, b EndPoint[T]) EndPoint[T] {
+	if d.after(a.Element, b.Element) {
+		return a
+	}
+	return b
+}
+
+// Returns the opposite type, i.e. the type of the adjoining end point of a neighbouring interval.
+func flip(x EndPointType) EndPointType {
+	if x == Open {
+		return Closed
+	}
+	return Open
+}
+
+// Returns the start of the span, treating instants as Closed.
+func (d Domain[T]) startPoint(a Span[T]) EndPoint[T] {
+	if d.IsInstant(a) {
+		return EndPoint[T]{a.Start(), Closed}
+	}
+	return EndPoint[T]{a.Start(), a.StartType()}
+}
+
+// Returns the end of the span, treating instants as Closed.
+func (d Domain[T]) endPoint(a Span[T]) EndPoint[T] {
+	if d.IsInstant(a) {
+		return EndPoint[T]{a.End(), Closed}
+	}
+	return EndPoint[T]{a.End(), a.EndType()}
+}
+
+// CompareStarts returns -1, 0 or +1 depending on whether the start of a comes before, at the same point as, or after
+// the start of b. A Closed start comes before an Open start at the same value, as it includes that value.
+func (d Domain[T]) CompareStarts(a, b Span[T]) int {
+	x, y := d.startPoint(a), d.startPoint(b)
+	switch {
+	case d.before(x.Element, y.Element):
+		return -1
+	case d.after(x.Element, y.Element):
+		return 1
+	case x.Type == y.Type:
+		return 0
+	case x.Type == Closed:
+		return -1
+	}
+	return 1
+}
+
+// CompareEnds returns -1, 0 or +1 depending on whether the end of a comes before, at the same point as, or after the
+// end of b. An Open end comes before a Closed end at the same value, as it doesn't include that value.
+func (d Domain[T]) CompareEnds(a, b Span[T]) int {
+	x, y := d.endPoint(a), d.endPoint(b)
+	switch {
+	case d.before(x.Element, y.Element):
+		return -1
+	case d.after(x.Element, y.Element):
+		return 1
+	case x.Type == y.Type:
+		return 0
+	case x.Type == Open:
+		return -1
+	}
+	return 1
+}
+
+func filter[T any](spans Spans[T], filterFunc func(Span[T]) bool) Spans[T] {
+	filtered := Spans[T]{}
+	for _, span := range spans {
+		if !filterFunc(span) {
+			filtered = append(filtered, span)
+		}
+	}
+	return filtered
+}
+
+// IsInstant returns true if the interval is deemed instantaneous. Any span which starts and ends at the same value is
+// treated as a Closed instant, whatever its types; see IsEmpty.
+func (d Domain[T]) IsInstant(a Span[T]) bool {
+	return d.equal(a.Start(), a.End())
+}
+
+// Contiguous returns true if a and b are side by side, so that together they cover a continuous span of values
+// without overlapping. For example, [1,2) and [2,3] are contiguous, but [1,2] and [2,3] (which overlap) and [1,2)
+// and (2,3] (which leave a gap) are not.
+func (d Domain[T]) Contiguous(a, b Span[T]) bool {
+	// [1,2,3,4] [4,5,6,7] - not contiguous
+	// [1,2,3,4) [4,5,6,7] - contiguous
+	// [1,2,3,4] (4,5,6,7] - contiguous
+	// [1,2,3,4) (4,5,6,7] - not contiguous
+	// [1,2,3] [5,6,7] - not contiguous
+	// [1] (1,2,3] - contiguous
+
+	// Two instants can't be contiguous
+	if d.IsInstant(a) && d.IsInstant(b) {
+		return false
+	}
+
+	if d.before(b.Start(), a.Start()) {
+		a, b = b, a
+	}
+
+	aStartType := a.StartType()
+	aEndType := a.EndType()
+	bStartType := b.StartType()
+
+	if d.IsInstant(a) {
+		aEndType = Closed
+		aStartType = Closed
+	}
+	if d.IsInstant(b) {
+		bStartType = Closed
+	}
+
+	// If a and b start at the same point, just check that their start types are different.
+	if d.equal(a.Start(), b.Start()) {
+		return aStartType != bStartType
+	}
+
+	// To be contiguous the ranges have to overlap on the first/last point
+	if !d.equal(a.End(), b.Start()) {
+		return false
+	}
+
+	if aEndType == bStartType {
+		return false
+	}
+	return true
+}
+
+// Overlaps returns true if there is any value covered by both a and b, taking the types of their end points into
+// account. For example, [1,2] and [2,3] overlap, but [1,2) and [2,3] don't.
+func (d Domain[T]) Overlaps(a, b Span[T]) bool {
+	// [1,2,3,4] [4,5,6,7] - intersects
+	// [1,2,3,4) [4,5,6,7] - doesn't intersect
+	// [1,2,3,4] (4,5,6,7] - doesn't intersect
+	// [1,2,3,4) (4,5,6,7] - doesn't intersect
+
+	aStartType := a.StartType()
+	aEndType := a.EndType()
+	bStartType := b.StartType()
+	bEndType := b.EndType()
+
+	if d.IsInstant(a) {
+		aStartType = Closed
+		aEndType = Closed
+	}
+	if d.IsInstant(b) {
+		bStartType = Closed
+		bEndType = Closed
+	}
+
+	// Given [a_s,a_e] and [b_s,b_e]
+	// If a_s > b_e || a_e < b_s, overlap == false
+
+	c1 := false // is a_s after b_e
+	if d.after(a.Start(), b.End()) {
+		c1 = true
+	} else if d.equal(a.Start(), b.End()) {
+		c1 = (aStartType == Open || bEndType == Open)
+	}
+
+	c2 := false // is a_e before b_s
+	if d.before(a.End(), b.Start()) {
+		c2 = true
+	} else if d.equal(a.End(), b.Start()) {
+		c2 = (aEndType == Open || bStartType == Open)
+	}
+
+	if c1 || c2 {
+		return false
+	}
+
+	return true
+}
+
+// Contains returns true if the value v lies within s, taking the types of its end points into account.
+func (d Domain[T]) Contains(s Span[T], v T) bool {
+	return d.Overlaps(s, NewInstant(v))
+}
+
+// ContainsSpan returns true if all of the values covered by inner are also covered by outer.
+func (d Domain[T]) ContainsSpan(outer, inner Span[T]) bool {
+	return d.CompareStarts(outer, inner) <= 0 && d.CompareEnds(inner, outer) <= 0
+}
+
+// Equal returns true if a and b cover exactly the same values, with the same types of end points. Instants are
+// treated as Closed at both ends, as they are elsewhere.
+func (d Domain[T]) Equal(a, b Span[T]) bool {
+	return d.CompareStarts(a, b) == 0 && d.CompareEnds(a, b) == 0
+}
+
+// Intersect returns the span covered by both a and b, which must overlap. Where the spans start or end at the same
+// value, the tighter of their types is used.
+func (d Domain[T]) Intersect(a, b Span[T]) Span[T] {
+	spanStart := d.getMax(EndPoint[T]{a.Start(), a.StartType()}, EndPoint[T]{b.Start(), b.StartType()})
+	spanEnd := d.getMin(EndPoint[T]{a.End(), a.EndType()}, EndPoint[T]{b.End(), b.EndType()})
+
+	if d.equal(a.Start(), b.Start()) {
+		spanStart.Type = getTightestIntervalType(a.StartType(), b.StartType())
+	}
+	if d.equal(a.End(), b.End()) {
+		spanEnd.Type = getTightestIntervalType(a.EndType(), b.EndType())
+	}
+	return d.New(spanStart.Element, spanEnd.Element, spanStart.Type, spanEnd.Type)
+}
+
+// Merge returns the span covering both a and b, which must overlap or be contiguous. Where the spans start or end at
+// the same value, the looser of their types is used.
+func (d Domain[T]) Merge(a, b Span[T]) Span[T] {
+	spanStart, spanEnd := d.MergePoints(a, b)
+	return d.New(spanStart.Element, spanEnd.Element, spanStart.Type, spanEnd.Type)
+}
+
+// MergePoints returns the start and end points of the span returned by Merge, without creating it.
+func (d Domain[T]) MergePoints(a, b Span[T]) (EndPoint[T], EndPoint[T]) {
+	spanStart := d.getMin(EndPoint[T]{a.Start(), a.StartType()}, EndPoint[T]{b.Start(), b.StartType()})
+	spanEnd := d.getMax(EndPoint[T]{a.End(), a.EndType()}, EndPoint[T]{b.End(), b.EndType()})
+
+	if d.equal(a.Start(), b.Start()) {
+		spanStart.Type = getLoosestIntervalType(a.StartType(), b.StartType())
+	}
+	if d.equal(a.End(), b.End()) {
+		spanEnd.Type = getLoosestIntervalType(a.EndType(), b.EndType())
+	}
+	return spanStart, spanEnd
+}
+
+// UnionWithHandler returns a list of Spans representing the union of all of the spans.
+// For example, given a list [A,B] where A and B overlap, a list [C] would be returned, with the span C spanning
+// both A and B. The provided handler is passed the source and destination spans, and the currently merged empty span.
+func (d Domain[T]) UnionWithHandler(s Spans[T], unionHandlerFunc UnionHandlerFunc[T]) Spans[T] {
+
+	if len(s) < 2 {
+		return s
+	}
+
+	sorted := d.sortedByStart(s)
+	result := Spans[T]{sorted[0]}
+
+	for _, b := range sorted[1:] {
+		// A: current span in merged array; B: current span in sorted array
+		// If B overlaps with A, it can be merged with A.
+		a := result[len(result)-1]
+		if d.Overlaps(a, b) || d.Contiguous(a, b) {
+			result[len(result)-1] = unionHandlerFunc(a, b, d.Merge(a, b))
+			continue
+		}
+		result = append(result, b)
+	}
+
+	return result
+}
+
+// Union returns a list of Spans representing the union of all of the spans.
+// For example, given a list [A,B] where A and B overlap, a list [C] would be returned, with the span C spanning
+// both A and B.
+func (d Domain[T]) Union(s Spans[T]) Spans[T] {
+	return d.UnionWithHandler(s, func(mergeInto, mergeFrom, mergeSpan Span[T]) Span[T] {
+		return mergeSpan
+	})
+}
+
+// IntersectionWithHandler returns a list of Spans representing the overlaps between the given spans.
+// For example, given a list [A,B] where A and B overlap, a list [C] would be returned, with the span C covering
+// the intersection of the A and B. The provided handler function is notified of the two spans that have been found
+// to overlap, and the span representing the overlap.
+func (d Domain[T]) IntersectionWithHandler(s Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T]) Spans[T] {
+	intersections := Spans[T]{}
+	d.eachIntersection(s, intersectHandlerFunc, func(intersection Span[T]) bool {
+		intersections = append(intersections, intersection)
+		return true
+	})
+	return intersections
+}
+
+// Calls yield with each of the overlaps between the spans, as returned by IntersectionWithHandler, until yield
+// returns false.
+func (d Domain[T]) eachIntersection(s Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T], yield func(Span[T]) bool) {
+	if len(s) == 0 {
+		return
+	}
+
+	sorted := d.sortedByStart(s)
+	actives := Spans[T]{sorted[0]}
+
+	for _, b := range sorted[1:] {
+		// Tidy up the active span list
+		actives = filter(actives, func(t Span[T]) bool {
+			// If this value is identical to one in actives, don't filter it.
+			if d.equal(b.Start(), t.Start()) && d.equal(b.End(), t.End()) {
+				return false
+			}
+			// If this value starts after the one in actives finishes, filter the active.
+			return d.after(b.Start(), t.End())
+		})
+
+		for _, a := range actives {
+			if d.Overlaps(a, b) {
+				if !yield(intersectHandlerFunc(a, b, d.Intersect(a, b))) {
+					return
+				}
+			}
+		}
+		actives = append(actives, b)
+	}
+}
+
+// Intersection returns a list of Spans representing the overlaps between the given spans.
+// For example, given a list [A,B] where A and B overlap, a list [C] would be returned,
+// with the span C covering the intersection of A and B.
+func (d Domain[T]) Intersection(s Spans[T]) Spans[T] {
+	return d.IntersectionWithHandler(s, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span[T]) Span[T] {
+		return intersectionSpan
+	})
+}
+
+// IntersectionBetweenWithHandler returns a list of Spans representing the overlaps between the spans in s and a given
+// set of spans. It calls intersectHandlerFunc for each pair of spans that are intersected.
+func (d Domain[T]) IntersectionBetweenWithHandler(s, candidates Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T]) Spans[T] {
+	intersections := Spans[T]{}
+	d.eachIntersectionBetween(s, candidates, intersectHandlerFunc, func(intersection Span[T]) bool {
+		intersections = append(intersections, intersection)
+		return true
+	})
+	return intersections
+}
+
+// Calls yield with each of the overlaps between the spans in s and the candidates, as returned by
+// IntersectionBetweenWithHandler, until yield returns false.
+// The spans in s are sorted by start once, along with the latest end of the spans up to each of them, so that the
+// spans which may overlap a candidate can be found by binary search. The candidates are then visited in order, finding
+// the overlaps of each only when they are needed, in the order of the spans in s, with the same handler calls as
+// comparing every pair.
+func (d Domain[T]) eachIntersectionBetween(s, candidates Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T], yield func(Span[T]) bool) {
+	if len(s) == 0 {
+		return
+	}
+
+	order := d.indicesByStart(s)
+	// Unlike the ends of the sorted spans, the latest end of the spans so far only ever increases, so it can be searched.
+	latestEnds := make([]T, len(order))
+	for i, si := range order {
+		latestEnds[i] = s[si].End()
+		if i > 0 && d.before(latestEnds[i], latestEnds[i-1]) {
+			latestEnds[i] = latestEnds[i-1]
+		}
+	}
+
+	overlapping := []int{}
+	for _, candidate := range candidates {
+		// Spans up to the first to end at or after the candidate's start all end before it, and spans from the first to
+		// start after the candidate's end all start after it, so neither can overlap it.
+		from := sort.Search(len(order), func(i int) bool { return !d.before(latestEnds[i], candidate.Start()) })
+		to := sort.Search(len(order), func(i int) bool { return d.after(s[order[i]].Start(), candidate.End()) })
+
+		overlapping = overlapping[:0]
+		for _, si := range order[from:max(from, to)] {
+			if d.Overlaps(s[si], candidate) {
+				overlapping = append(overlapping, si)
+			}
+		}
+		slices.Sort(overlapping)
+
+		for _, si := range overlapping {
+			span := s[si]
+			// The intersection is taken with the spans in the order they are sorted in by Intersection.
+			first, second := candidate, span
+			if d.before(span.Start(), candidate.Start()) {
+				first, second = span, candidate
+			}
+			if !yield(intersectHandlerFunc(candidate, span, d.Intersect(first, second))) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the indices of the spans, sorted by their start values.
+func (d Domain[T]) indicesByStart(s Spans[T]) []int {
+	indices := make([]int, len(s))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(i, j int) int { return d.Compare(s[i].Start(), s[j].Start()) })
+	return indices
+}
+
+// IntersectionBetween returns the slice of spans representing the overlaps between the spans in s and a given set of
+// spans.
+func (d Domain[T]) IntersectionBetween(s, b Spans[T]) Spans[T] {
+	return d.IntersectionBetweenWithHandler(s, b, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span[T]) Span[T] {
+		return intersectionSpan
+	})
+}
+
+// IntersectAllHandlerFunc is used by IntersectAllWithHandler to allow for custom functionality when values are found
+// to be covered by every list. It is passed the span from each list which covers the intersection, in the order the
+// lists were given, and the span representing the intersection.
+type IntersectAllHandlerFunc[T any] func(intersectingSpans Spans[T], intersectionSpan Span[T]) Span[T]
+
+type intersectAllPartial[T any] struct {
+	span        Span[T]
+	intersected Spans[T]
+}
+
+// IntersectAllWithHandler returns a list of Spans representing the values covered by every one of the given lists.
+// For example, given lists [A], [B] and [C] where A, B and C all overlap, a list [D] would be returned, with the span D
+// covering the values common to A, B and C. Each list is merged as by Union first, so that the handler is passed the
+// merged span from each list which covers the intersection, along with the span representing the intersection.
+func (d Domain[T]) IntersectAllWithHandler(intersectAllHandlerFunc IntersectAllHandlerFunc[T], lists ...Spans[T]) Spans[T] {
+	intersections := Spans[T]{}
+	if len(lists) == 0 {
+		return intersections
+	}
+
+	var partials []intersectAllPartial[T]
+	for _, span := range d.Union(lists[0]) {
+		partials = append(partials, intersectAllPartial[T]{span, Spans[T]{span}})
+	}
+
+	for _, list := range lists[1:] {
+		// Both the partial intersections and the merged list are sorted and don't overlap themselves, so they can be
+		// walked together, moving on from whichever span finishes first.
+		merged := d.Union(list)
+		var next []intersectAllPartial[T]
+		for i, j := 0, 0; i < len(partials) && j < len(merged); {
+			a, b := partials[i], merged[j]
+			if d.Overlaps(a.span, b) {
+				intersected := append(append(Spans[T]{}, a.intersected...), b)
+				next = append(next, intersectAllPartial[T]{d.Intersect(a.span, b), intersected})
+			}
+
+			switch d.CompareEnds(a.span, b) {
+			case -1:
+				i++
+			case 1:
+				j++
+			default:
+				i++
+				j++
+			}
+		}
+		partials = next
+	}
+
+	for _, p := range partials {
+		intersections = append(intersections, intersectAllHandlerFunc(p.intersected, p.span))
+	}
+	return intersections
+}
+
+// IntersectAll returns a list of Spans representing the values covered by every one of the given lists.
+// For example, given lists [A], [B] and [C] where A, B and C all overlap, a list [D] would be returned, with the span D
+// covering the values common to A, B and C.
+func (d Domain[T]) IntersectAll(lists ...Spans[T]) Spans[T] {
+	return d.IntersectAllWithHandler(func(intersectingSpans Spans[T], intersectionSpan Span[T]) Span[T] {
+		return intersectionSpan
+	}, lists...)
+}
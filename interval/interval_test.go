@@ -0,0 +1,112 @@
+package interval_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+func expectEqual(t *testing.T, actual, expected interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %v to equal %v", actual, expected)
+	}
+}
+
+var ints = interval.Ordered[int]()
+
+func TestOrdered(t *testing.T) {
+	a := interval.New(1, 5)
+	b := interval.New(3, 8)
+	c := interval.New(10, 12)
+	d := interval.NewWithTypes(12, 15, interval.Open, interval.Open)
+
+	t.Run("Should merge overlapping spans", func(t *testing.T) {
+		expectEqual(t, ints.Union(interval.Spans[int]{c, b, a}), interval.Spans[int]{interval.New(1, 8), c})
+	})
+
+	t.Run("Should not merge spans which leave a gap", func(t *testing.T) {
+		expectEqual(t, ints.Union(interval.Spans[int]{c, d}), interval.Spans[int]{c, d})
+	})
+
+	t.Run("Should find intersections", func(t *testing.T) {
+		expectEqual(t, ints.Intersection(interval.Spans[int]{a, b, c}), interval.Spans[int]{interval.New(3, 5)})
+		expectEqual(t, ints.IntersectionBetween(interval.Spans[int]{a, c}, interval.Spans[int]{b}), interval.Spans[int]{interval.New(3, 5)})
+	})
+
+	t.Run("Should find differences", func(t *testing.T) {
+		expectEqual(t, ints.Difference(interval.Spans[int]{interval.New(0, 20)}, interval.Spans[int]{a, c}), interval.Spans[int]{
+			interval.New(0, 1),
+			interval.New(5, 10),
+			interval.New(12, 20),
+		})
+		expectEqual(t, ints.Complement(interval.Spans[int]{b}, interval.New(0, 10)), interval.Spans[int]{
+			interval.New(0, 3),
+			interval.New(8, 10),
+		})
+	})
+
+	t.Run("Should count coverage", func(t *testing.T) {
+		expectEqual(t, ints.Coverage(interval.Spans[int]{a, b}), []interval.CoverageSpan[int]{
+			{interval.New(1, 3), 1},
+			{interval.New(3, 5), 2},
+			{interval.New(5, 8), 1},
+		})
+	})
+
+	t.Run("Should relate spans", func(t *testing.T) {
+		expectEqual(t, ints.Relate(a, b), interval.RelationOverlaps)
+		expectEqual(t, ints.Relate(c, d), interval.RelationBefore)
+		expectEqual(t, ints.Relate(c, interval.New(12, 15)), interval.RelationMeets)
+		expectEqual(t, ints.Contains(a, 1), true)
+		expectEqual(t, ints.Contains(a, 5), false)
+	})
+
+	t.Run("Should operate on floats", func(t *testing.T) {
+		floats := interval.Ordered[float64]()
+		spans := interval.Spans[float64]{interval.New(0.5, 1.5), interval.New(1.5, 2.25)}
+		expectEqual(t, floats.Union(spans), interval.Spans[float64]{interval.New(0.5, 2.25)})
+	})
+}
+
+// A version number, such as 1.10, which is ordered by its parts rather than as a string or float.
+type version struct {
+	major, minor int
+}
+
+func TestDomain(t *testing.T) {
+	versions := interval.Domain[version]{
+		Compare: func(a, b version) int {
+			if a.major != b.major {
+				return a.major - b.major
+			}
+			return a.minor - b.minor
+		},
+		New: func(start, end version, startType, endType interval.EndPointType) interval.Span[version] {
+			return interval.NewWithTypes(start, end, startType, endType)
+		},
+	}
+
+	t.Run("Should order values with Compare", func(t *testing.T) {
+		spans := interval.Spans[version]{
+			interval.New(version{1, 9}, version{1, 12}),
+			interval.New(version{1, 2}, version{1, 10}),
+		}
+		expectEqual(t, versions.Union(spans), interval.Spans[version]{interval.New(version{1, 2}, version{1, 12})})
+	})
+
+	t.Run("Should pass merged spans to the handler", func(t *testing.T) {
+		spans := interval.Spans[version]{
+			interval.New(version{1, 0}, version{2, 0}),
+			interval.New(version{2, 0}, version{3, 0}),
+		}
+		var merged []string
+		versions.UnionWithHandler(spans, func(mergeInto, mergeFrom, mergeSpan interval.Span[version]) interval.Span[version] {
+			merged = append(merged, mergeSpan.(*interval.Range[version]).String())
+			return mergeSpan
+		})
+		expectEqual(t, strings.Join(merged, ","), "[{1 0},{3 0})")
+	})
+}
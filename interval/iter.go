@@ -0,0 +1,133 @@
+package interval
+
+import (
+	"errors"
+	"iter"
+)
+
+// All returns an iterator over the spans, in order.
+func (s Spans[T]) All() iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		for _, span := range s {
+			if !yield(span) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns a list of the spans produced by seq.
+func Collect[T any](seq iter.Seq[Span[T]]) Spans[T] {
+	spans := Spans[T]{}
+	for span := range seq {
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// Used to stop UnionStream when the consumer of an iterator stops early.
+var errStopped = errors.New("stopped")
+
+// UnionWithHandlerSeq returns an iterator over the spans returned by UnionWithHandler. Each merged span is produced
+// as soon as no later span can be merged with it, so stopping early avoids merging the rest of the spans.
+func (d Domain[T]) UnionWithHandlerSeq(s Spans[T], unionHandlerFunc UnionHandlerFunc[T]) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		if len(s) < 2 {
+			s.All()(yield)
+			return
+		}
+
+		sorted, i := d.sortedByStart(s), 0
+		next := func() (Span[T], bool) {
+			if i == len(sorted) {
+				return nil, false
+			}
+			i++
+			return sorted[i-1], true
+		}
+		_ = d.UnionStreamWithHandler(next, unionHandlerFunc, func(span Span[T]) error {
+			if !yield(span) {
+				return errStopped
+			}
+			return nil
+		})
+	}
+}
+
+// UnionSeq returns an iterator over the spans returned by Union. See UnionWithHandlerSeq.
+func (d Domain[T]) UnionSeq(s Spans[T]) iter.Seq[Span[T]] {
+	return d.UnionWithHandlerSeq(s, func(mergeInto, mergeFrom, mergeSpan Span[T]) Span[T] {
+		return mergeSpan
+	})
+}
+
+// UnionSorted returns an iterator which merges the spans produced by seq, which must be sorted by their start values,
+// in the same way as UnionStream. Each merged span is produced with a nil error as soon as no later span can be merged
+// with it. If a span starts before the span preceding it, ErrOutOfOrder is produced with a nil span, and the iterator
+// stops.
+func (d Domain[T]) UnionSorted(seq iter.Seq[Span[T]]) iter.Seq2[Span[T], error] {
+	return func(yield func(Span[T], error) bool) {
+		next, stop := iter.Pull(seq)
+		defer stop()
+		err := d.UnionStream(next, func(span Span[T]) error {
+			if !yield(span, nil) {
+				return errStopped
+			}
+			return nil
+		})
+		if err == ErrOutOfOrder {
+			yield(nil, err)
+		}
+	}
+}
+
+// IntersectionWithHandlerSeq returns an iterator over the spans returned by IntersectionWithHandler, which finds each
+// overlap only as it is needed.
+func (d Domain[T]) IntersectionWithHandlerSeq(s Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T]) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		d.eachIntersection(s, intersectHandlerFunc, yield)
+	}
+}
+
+// IntersectionSeq returns an iterator over the spans returned by Intersection. See IntersectionWithHandlerSeq.
+func (d Domain[T]) IntersectionSeq(s Spans[T]) iter.Seq[Span[T]] {
+	return d.IntersectionWithHandlerSeq(s, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span[T]) Span[T] {
+		return intersectionSpan
+	})
+}
+
+// IntersectionBetweenWithHandlerSeq returns an iterator over the spans returned by IntersectionBetweenWithHandler,
+// which finds each overlap only as it is needed.
+func (d Domain[T]) IntersectionBetweenWithHandlerSeq(s, candidates Spans[T], intersectHandlerFunc IntersectionHandlerFunc[T]) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		d.eachIntersectionBetween(s, candidates, intersectHandlerFunc, yield)
+	}
+}
+
+// IntersectionBetweenSeq returns an iterator over the spans returned by IntersectionBetween. See
+// IntersectionBetweenWithHandlerSeq.
+func (d Domain[T]) IntersectionBetweenSeq(s, candidates Spans[T]) iter.Seq[Span[T]] {
+	return d.IntersectionBetweenWithHandlerSeq(s, candidates, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span[T]) Span[T] {
+		return intersectionSpan
+	})
+}
+
+// DifferenceWithHandlerSeq returns an iterator over the spans returned by DifferenceWithHandler, which cuts each span
+// only as it is needed.
+func (d Domain[T]) DifferenceWithHandlerSeq(s, other Spans[T], differenceHandlerFunc DifferenceHandlerFunc[T]) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		d.eachDifference(s, other, differenceHandlerFunc, yield)
+	}
+}
+
+// DifferenceSeq returns an iterator over the spans returned by Difference. See DifferenceWithHandlerSeq.
+func (d Domain[T]) DifferenceSeq(s, other Spans[T]) iter.Seq[Span[T]] {
+	return d.DifferenceWithHandlerSeq(s, other, func(subtractFrom, differenceSpan Span[T]) Span[T] {
+		return differenceSpan
+	})
+}
+
+// ComplementSeq returns an iterator over the spans returned by Complement.
+func (d Domain[T]) ComplementSeq(s Spans[T], window Span[T]) iter.Seq[Span[T]] {
+	return d.DifferenceSeq(Spans[T]{window}, s)
+}
@@ -0,0 +1,106 @@
+package interval
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// MergeSortedHandlerFunc is used by MergeSortedWithHandler to allow for custom functionality when spans from the
+// lists are merged. It is passed the indices of the lists which the spans merged came from, in ascending order, and
+// the span which will result from the merge.
+type MergeSortedHandlerFunc[T any] func(sources []int, mergeSpan Span[T]) Span[T]
+
+// The position of the next span to be merged from one of the lists.
+type mergeCursor struct {
+	list, position int
+}
+
+// A heap of the next span from each of the lists, ordered by start value, and then by list so that spans from
+// earlier lists come first, as they would if the lists were joined and sorted.
+type mergeHeap[T any] struct {
+	d       Domain[T]
+	lists   []Spans[T]
+	cursors []mergeCursor
+}
+
+func (h *mergeHeap[T]) span(i int) Span[T] {
+	return h.lists[h.cursors[i].list][h.cursors[i].position]
+}
+
+func (h *mergeHeap[T]) Len() int      { return len(h.cursors) }
+func (h *mergeHeap[T]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+func (h *mergeHeap[T]) Push(x any)    { h.cursors = append(h.cursors, x.(mergeCursor)) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.d.Compare(h.span(i).Start(), h.span(j).Start()); c != 0 {
+		return c < 0
+	}
+	return h.cursors[i].list < h.cursors[j].list
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
+
+// MergeSortedWithHandler returns the union of several lists of spans, each of which must already be sorted by start
+// value, as returned by Union. The result is the same as joining the lists together and calling UnionWithHandler,
+// but rather than sorting all of the spans again, the next span from each list is kept in a heap, so that merging n
+// spans from k lists takes O(n log k) time.
+// The provided handler is called for every span returned, including spans which weren't merged with any other, and is
+// passed the indices of the lists the span drew from, along with the span itself. If a span starts before the span
+// preceding it in the same list, ErrOutOfOrder is returned.
+func (d Domain[T]) MergeSortedWithHandler(mergeSortedHandlerFunc MergeSortedHandlerFunc[T], lists ...Spans[T]) (Spans[T], error) {
+	h := &mergeHeap[T]{d: d, lists: lists}
+	for list, spans := range lists {
+		if len(spans) > 0 {
+			h.cursors = append(h.cursors, mergeCursor{list, 0})
+		}
+	}
+	heap.Init(h)
+
+	merged := Spans[T]{}
+	var current Span[T]
+	var sources []int
+	for h.Len() > 0 {
+		b, list := h.span(0), h.cursors[0].list
+
+		// Move on to the next span in the same list, which can't start before this one.
+		if position := h.cursors[0].position + 1; position < len(lists[list]) {
+			if d.before(lists[list][position].Start(), b.Start()) {
+				return nil, ErrOutOfOrder
+			}
+			h.cursors[0].position = position
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+
+		switch {
+		case current == nil:
+			current, sources = b, []int{list}
+		case d.Overlaps(current, b) || d.Contiguous(current, b):
+			current = d.Merge(current, b)
+			if i, found := slices.BinarySearch(sources, list); !found {
+				sources = slices.Insert(sources, i, list)
+			}
+		default:
+			merged = append(merged, mergeSortedHandlerFunc(sources, current))
+			current, sources = b, []int{list}
+		}
+	}
+
+	if current != nil {
+		merged = append(merged, mergeSortedHandlerFunc(sources, current))
+	}
+	return merged, nil
+}
+
+// MergeSorted returns the union of several lists of spans, each of which must already be sorted by start value, as
+// returned by Union. See MergeSortedWithHandler.
+func (d Domain[T]) MergeSorted(lists ...Spans[T]) (Spans[T], error) {
+	return d.MergeSortedWithHandler(func(sources []int, mergeSpan Span[T]) Span[T] {
+		return mergeSpan
+	}, lists...)
+}
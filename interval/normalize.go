@@ -0,0 +1,37 @@
+package interval
+
+import (
+	"slices"
+)
+
+// IsEmpty returns true if the span doesn't include any values at all. This is the case if it ends before it starts,
+// or if it starts and ends at the same value but isn't Closed at both ends, such as (1,1] or [1,1). Note that
+// IsInstant returns true for any span which starts and ends at the same value, and the other operations treat such
+// spans as Closed instants; use Normalize to remove empty spans before operating on them if that isn't wanted.
+func (d Domain[T]) IsEmpty(a Span[T]) bool {
+	if d.before(a.End(), a.Start()) {
+		return true
+	}
+	return d.IsInstant(a) && (a.StartType() == Open || a.EndType() == Open)
+}
+
+// CompareSpans returns -1, 0 or +1 depending on whether a comes before, at the same point as, or after b, when sorted
+// by their start points and then by their end points as by Normalize.
+func (d Domain[T]) CompareSpans(a, b Span[T]) int {
+	if c := d.CompareStarts(a, b); c != 0 {
+		return c
+	}
+	return d.CompareEnds(a, b)
+}
+
+// Normalize returns a list of the spans in a canonical form, so that the results of the other operations are
+// consistent regardless of how the spans were constructed. Empty spans, as reported by IsEmpty, are removed, so the
+// only spans left which start and end at the same value are instants which are Closed at both ends.
+// The remaining spans are sorted by their start points and then by their end points, with a Closed start coming
+// before an Open start at the same value, and an Open end before a Closed end, so that an instant comes before any
+// other span starting at the same value. Spans which are otherwise equal keep their original order.
+func (d Domain[T]) Normalize(s Spans[T]) Spans[T] {
+	normalized := filter(s, d.IsEmpty)
+	slices.SortStableFunc(normalized, d.CompareSpans)
+	return normalized
+}
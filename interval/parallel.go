@@ -0,0 +1,219 @@
+package interval
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Returns the number of workers to split n spans between, given the number requested, where zero or less means one
+// for each processor.
+func workerCount(workers, n int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return max(min(workers, n), 1)
+}
+
+// Returns the boundaries of n items split into the given number of chunks of roughly equal size, so that chunk i is
+// [bounds[i],bounds[i+1]).
+func chunkBounds(n, chunks int) []int {
+	bounds := make([]int, chunks+1)
+	for i := range bounds {
+		bounds[i] = i * n / chunks
+	}
+	return bounds
+}
+
+// Calls f with each of 0 to n-1 concurrently, returning when all of the calls have returned.
+func inParallel(n int, f func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			f(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// Returns a copy of the spans sorted as by sortedByStart, sorting chunks of them concurrently and then merging the
+// sorted chunks together.
+func (d Domain[T]) sortedByStartParallel(s Spans[T], workers int) Spans[T] {
+	sorted := append(Spans[T]{}, s...)
+	bounds := chunkBounds(len(sorted), workers)
+	inParallel(workers, func(i int) {
+		d.sortByStart(sorted[bounds[i]:bounds[i+1]])
+	})
+
+	// Merge neighbouring pairs of sorted runs concurrently, until a single run is left.
+	buffer := make(Spans[T], len(sorted))
+	for len(bounds) > 2 {
+		runs := len(bounds) - 1
+		merged := []int{0}
+		inParallel((runs+1)/2, func(i int) {
+			lo, mid, hi := bounds[2*i], bounds[2*i+1], bounds[min(2*i+2, runs)]
+			d.mergeRuns(buffer[lo:hi], sorted[lo:mid], sorted[mid:hi])
+		})
+		for r := 2; r < runs; r += 2 {
+			merged = append(merged, bounds[r])
+		}
+		merged = append(merged, bounds[runs])
+
+		sorted, buffer = buffer, sorted
+		bounds = merged
+	}
+	return sorted
+}
+
+// Merges the sorted runs a and b into dst, taking from a when spans start together, so that the merge is stable.
+func (d Domain[T]) mergeRuns(dst, a, b Spans[T]) {
+	i, j := 0, 0
+	for k := range dst {
+		if j == len(b) || (i < len(a) && !d.before(b[j].Start(), a[i].Start())) {
+			dst[k] = a[i]
+			i++
+			continue
+		}
+		dst[k] = b[j]
+		j++
+	}
+}
+
+// The result of merging a chunk of sorted spans on its own, along with the index within the chunk of the first span
+// of each merged span.
+type unionChunk[T any] struct {
+	merged Spans[T]
+	starts []int
+}
+
+// Merges a chunk of sorted spans in the same way as UnionWithHandler.
+func (d Domain[T]) unionChunk(sorted Spans[T]) unionChunk[T] {
+	chunk := unionChunk[T]{Spans[T]{sorted[0]}, []int{0}}
+	for i, b := range sorted[1:] {
+		a := chunk.merged[len(chunk.merged)-1]
+		if d.Overlaps(a, b) || d.Contiguous(a, b) {
+			chunk.merged[len(chunk.merged)-1] = d.Merge(a, b)
+			continue
+		}
+		chunk.merged = append(chunk.merged, b)
+		chunk.starts = append(chunk.starts, i+1)
+	}
+	return chunk
+}
+
+// UnionParallel returns the same list of Spans as Union, splitting the work of sorting and merging the spans between
+// the given number of goroutines, or one for each processor if workers is zero or less.
+// The sorted spans are split into a chunk for each worker, which are merged concurrently. The merged chunks are then
+// joined in order, by carrying on merging the spans at the start of each chunk into the last merged span of the chunks
+// before it, until the merge starts a new span at the same place as the chunk's own merge did, from which point the
+// chunk's own merged spans are used. This is only worthwhile for long lists of spans, such as hundreds of thousands.
+func (d Domain[T]) UnionParallel(s Spans[T], workers int) Spans[T] {
+	if len(s) < 2 {
+		return s
+	}
+
+	workers = workerCount(workers, len(s))
+	sorted := d.sortedByStartParallel(s, workers)
+	bounds := chunkBounds(len(sorted), workers)
+	chunks := make([]unionChunk[T], workers)
+	inParallel(workers, func(i int) {
+		chunks[i] = d.unionChunk(sorted[bounds[i]:bounds[i+1]])
+	})
+
+	result := chunks[0].merged
+	for c, chunk := range chunks[1:] {
+		spans := sorted[bounds[c+1]:bounds[c+2]]
+		next := 0
+		for i, b := range spans {
+			a := result[len(result)-1]
+			if d.Overlaps(a, b) || d.Contiguous(a, b) {
+				result[len(result)-1] = d.Merge(a, b)
+				continue
+			}
+
+			// A new merged span starts here, so if the chunk's own merge started one here too, the rest agrees.
+			for next < len(chunk.starts) && chunk.starts[next] < i {
+				next++
+			}
+			if next < len(chunk.starts) && chunk.starts[next] == i {
+				result = append(result, chunk.merged[next:]...)
+				break
+			}
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// IntersectionParallel returns the same list of Spans as Intersection, splitting the work of sorting the spans and
+// finding their overlaps between the given number of goroutines, or one for each processor if workers is zero or less.
+// The sorted spans are split into a chunk for each worker, each of which finds the overlaps of the spans in its chunk
+// with those before them, so the overlaps found by each worker follow on from those found by the worker before it.
+// This is only worthwhile for long lists of spans, such as hundreds of thousands.
+func (d Domain[T]) IntersectionParallel(s Spans[T], workers int) Spans[T] {
+	if len(s) == 0 {
+		return Spans[T]{}
+	}
+
+	workers = workerCount(workers, len(s))
+	sorted := d.sortedByStartParallel(s, workers)
+	bounds := chunkBounds(len(sorted), workers)
+	chunks := make([]Spans[T], workers)
+	inParallel(workers, func(i int) {
+		chunks[i] = d.intersectionChunk(sorted, bounds[i], bounds[i+1])
+	})
+
+	intersections := Spans[T]{}
+	for _, chunk := range chunks {
+		intersections = append(intersections, chunk...)
+	}
+	return intersections
+}
+
+// Returns the overlaps found by Intersection for the sorted spans from lo to hi, with the spans before them.
+func (d Domain[T]) intersectionChunk(sorted Spans[T], lo, hi int) Spans[T] {
+	// Find the spans which Intersection would have left active after the span before lo. Spans are dropped once a span
+	// starts after they end, unless the two are identical, and as the spans are sorted, the span before lo starts the
+	// latest of those after each span.
+	actives := Spans[T]{}
+	for i, t := range sorted[:lo] {
+		if d.activeUntil(sorted, i, lo) {
+			actives = append(actives, t)
+		}
+	}
+
+	intersections := Spans[T]{}
+	for _, b := range sorted[lo:hi] {
+		actives = filter(actives, func(t Span[T]) bool {
+			if d.equal(b.Start(), t.Start()) && d.equal(b.End(), t.End()) {
+				return false
+			}
+			return d.after(b.Start(), t.End())
+		})
+
+		for _, a := range actives {
+			if d.Overlaps(a, b) {
+				intersections = append(intersections, d.Intersect(a, b))
+			}
+		}
+		actives = append(actives, b)
+	}
+	return intersections
+}
+
+// Returns true if the sorted span at index i is still active in Intersection after the spans up to lo.
+func (d Domain[T]) activeUntil(sorted Spans[T], i, lo int) bool {
+	t := sorted[i]
+	if !d.before(t.End(), t.Start()) {
+		return i == lo-1 || !d.after(sorted[lo-1].Start(), t.End())
+	}
+
+	// A span which ends before it starts is dropped by the first span after it which isn't identical to it.
+	for _, b := range sorted[i+1 : lo] {
+		if !d.equal(b.Start(), t.Start()) || !d.equal(b.End(), t.End()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package interval
+
+import (
+	"fmt"
+)
+
+// Range represents a simple span of values, with no additional properties. It should be constructed with New,
+// NewWithTypes or NewInstant.
+type Range[T any] struct {
+	start     T
+	end       T
+	startType EndPointType
+	endType   EndPointType
+}
+
+// Start returns the start value of a span
+func (r Range[T]) Start() T { return r.start }
+
+// End returns the end value of a span
+func (r Range[T]) End() T { return r.end }
+
+// StartType returns the type of the start of the interval
+func (r Range[T]) StartType() EndPointType { return r.startType }
+
+// EndType returns the type of the end of the interval
+func (r Range[T]) EndType() EndPointType { return r.endType }
+
+// String returns a string representation of a range
+func (r Range[T]) String() string {
+	s := "("
+	if r.startType == Closed {
+		s = "["
+	}
+	s += fmt.Sprintf("%v,%v", r.start, r.end)
+	if r.endType == Closed {
+		return s + "]"
+	}
+	return s + ")"
+}
+
+// NewWithTypes creates a span with just a start and end value, and associated types.
+func NewWithTypes[T any](start, end T, startType, endType EndPointType) *Range[T] {
+	return &Range[T]{start, end, startType, endType}
+}
+
+// NewInstant creates a span with just a single value.
+func NewInstant[T any](v T) *Range[T] {
+	return NewWithTypes(v, v, Closed, Closed)
+}
+
+// New creates a span with a start and end value, with the types set to [] for instants and [) for spans.
+func New[T comparable](start, end T) *Range[T] {
+	if start == end {
+		// An instant has to be Closed (i.e. inclusive)
+		return NewWithTypes(start, end, Closed, Closed)
+	}
+	return NewWithTypes(start, end, Closed, Open)
+}
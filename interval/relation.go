@@ -0,0 +1,122 @@
+package interval
+
+// Relation represents one of the thirteen relations of Allen's interval algebra, which describe how two spans are
+// ordered with respect to one another.
+type Relation int
+
+const (
+	// RelationBefore means that the first span finishes before the second starts, with a gap between them.
+	RelationBefore Relation = iota
+	// RelationMeets means that the first span finishes where the second starts, with no gap and no overlap.
+	RelationMeets
+	// RelationOverlaps means that the first span starts before the second, and finishes within it.
+	RelationOverlaps
+	// RelationStarts means that the spans start together, and the first finishes before the second.
+	RelationStarts
+	// RelationDuring means that the first span starts after and finishes before the second.
+	RelationDuring
+	// RelationFinishes means that the spans finish together, and the first starts after the second.
+	RelationFinishes
+	// RelationEquals means that the spans start and finish together.
+	RelationEquals
+	// RelationFinishedBy is the inverse of RelationFinishes.
+	RelationFinishedBy
+	// RelationContains is the inverse of RelationDuring.
+	RelationContains
+	// RelationStartedBy is the inverse of RelationStarts.
+	RelationStartedBy
+	// RelationOverlappedBy is the inverse of RelationOverlaps.
+	RelationOverlappedBy
+	// RelationMetBy is the inverse of RelationMeets.
+	RelationMetBy
+	// RelationAfter is the inverse of RelationBefore.
+	RelationAfter
+)
+
+var relationNames = [...]string{
+	"before",
+	"meets",
+	"overlaps",
+	"starts",
+	"during",
+	"finishes",
+	"equals",
+	"finished by",
+	"contains",
+	"started by",
+	"overlapped by",
+	"met by",
+	"after",
+}
+
+// String returns the name of the relation
+func (r Relation) String() string {
+	if r < 0 || int(r) >= len(relationNames) {
+		return "unknown"
+	}
+	return relationNames[r]
+}
+
+// Inverse returns the relation of the second span to the first, so that if Relate(a, b) returns r, Relate(b, a)
+// returns r.Inverse().
+func (r Relation) Inverse() Relation {
+	return RelationAfter - r
+}
+
+// Relate returns the relation of span a to span b, taking the types of their end points into account in the same way
+// as Overlaps and Contiguous.
+// For example, [1,2) meets [2,3], as they are contiguous, whereas [1,2] overlaps [2,3], and [1,2) is before (2,3].
+// A start which is Closed comes before an Open start at the same value, and an end which is Open comes before a Closed
+// end at the same value, so [1,3) starts [1,3], and (1,3] finishes [1,3].
+func (d Domain[T]) Relate(a, b Span[T]) Relation {
+	if !d.Overlaps(a, b) {
+		first := d.CompareStarts(a, b) <= 0
+		switch {
+		case d.Contiguous(a, b) && first:
+			return RelationMeets
+		case d.Contiguous(a, b):
+			return RelationMetBy
+		case first:
+			return RelationBefore
+		}
+		return RelationAfter
+	}
+
+	starts, ends := d.CompareStarts(a, b), d.CompareEnds(a, b)
+	switch {
+	case starts == 0 && ends == 0:
+		return RelationEquals
+	case starts == 0 && ends < 0:
+		return RelationStarts
+	case starts == 0:
+		return RelationStartedBy
+	case ends == 0 && starts > 0:
+		return RelationFinishes
+	case ends == 0:
+		return RelationFinishedBy
+	case starts > 0 && ends < 0:
+		return RelationDuring
+	case starts < 0 && ends > 0:
+		return RelationContains
+	case starts < 0:
+		return RelationOverlaps
+	}
+	return RelationOverlappedBy
+}
+
+// Related returns the list of spans in s which have one of the given relations to the reference span, as returned by
+// Relate(span, reference).
+// For example, calling Related with RelationDuring returns the spans which lie within the reference span.
+func (d Domain[T]) Related(s Spans[T], reference Span[T], relations ...Relation) Spans[T] {
+	related := Spans[T]{}
+	for _, span := range s {
+		r := d.Relate(span, reference)
+		for _, relation := range relations {
+			if r == relation {
+				related = append(related, span)
+				break
+			}
+		}
+	}
+	return related
+}
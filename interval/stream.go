@@ -0,0 +1,53 @@
+package interval
+
+import (
+	"errors"
+)
+
+// ErrOutOfOrder is returned by UnionStream when a span starts before the span preceding it.
+var ErrOutOfOrder = errors.New("span starts before the span preceding it")
+
+// UnionStreamWithHandler merges a stream of spans, sorted by their start values, in the same way as UnionWithHandler,
+// without holding more than one merged span in memory at a time.
+// The spans are read by calling next until it returns false. Each merged span is passed to emit as soon as no later
+// span can be merged with it. If emit returns an error, no more spans are read and that error is returned. If a span
+// starts before the span preceding it, ErrOutOfOrder is returned, and spans which have already been emitted are left
+// as they are. The provided handler is passed the source and destination spans, and the currently merged empty span.
+func (d Domain[T]) UnionStreamWithHandler(next func() (Span[T], bool), unionHandlerFunc UnionHandlerFunc[T], emit func(Span[T]) error) error {
+	a, ok := next()
+	if !ok {
+		return nil
+	}
+	previous := a.Start()
+
+	for {
+		b, ok := next()
+		if !ok {
+			break
+		}
+		if d.before(b.Start(), previous) {
+			return ErrOutOfOrder
+		}
+		previous = b.Start()
+
+		// A: the span currently being merged; B: the next span in the stream
+		// If B overlaps with A, it can be merged with A, otherwise nothing further can be merged with A.
+		if d.Overlaps(a, b) || d.Contiguous(a, b) {
+			a = unionHandlerFunc(a, b, d.Merge(a, b))
+			continue
+		}
+		if err := emit(a); err != nil {
+			return err
+		}
+		a = b
+	}
+	return emit(a)
+}
+
+// UnionStream merges a stream of spans, sorted by their start values, in the same way as Union, without holding more
+// than one merged span in memory at a time. See UnionStreamWithHandler.
+func (d Domain[T]) UnionStream(next func() (Span[T], bool), emit func(Span[T]) error) error {
+	return d.UnionStreamWithHandler(next, func(mergeInto, mergeFrom, mergeSpan Span[T]) Span[T] {
+		return mergeSpan
+	}, emit)
+}
@@ -0,0 +1,84 @@
+package interval
+
+import (
+	"sort"
+)
+
+// Calls yield with each of the spans in s from index from which overlap the window. The spans must be sorted by start
+// value, but may overlap one another.
+func (d Domain[T]) window(s Spans[T], window Span[T], from int, yield func(Span[T])) {
+	// The spans starting after the window can be skipped by binary search, but as the spans may overlap, their ends
+	// aren't sorted, so a span starting long before the window may still reach into it.
+	to := sort.Search(len(s), func(i int) bool { return d.after(s[i].Start(), window.End()) })
+	for _, span := range s[from:max(from, to)] {
+		if d.Overlaps(span, window) {
+			yield(span)
+		}
+	}
+}
+
+// Window returns the spans in s which overlap the window, in the same order, taking the types of their end points into
+// account. The spans must be sorted by start value, but may overlap one another. The spans starting after the window
+// are skipped by binary search, but those starting before the end of the window are each compared with it, so for
+// spans which don't overlap one another, such as those returned by Union, WindowDisjoint is faster.
+func (d Domain[T]) Window(s Spans[T], window Span[T]) Spans[T] {
+	windowed := Spans[T]{}
+	d.window(s, window, 0, func(span Span[T]) {
+		windowed = append(windowed, span)
+	})
+	return windowed
+}
+
+// WindowDisjoint returns the spans in s which overlap the window in the same way as Window. The spans must be sorted
+// by start value and must not overlap one another, as returned by Union, so that both the first and the last of them
+// to overlap the window can be found by binary search, in O(log n + k) time for k spans returned. The spans returned
+// can be cut to the window with Clip.
+func (d Domain[T]) WindowDisjoint(s Spans[T], window Span[T]) Spans[T] {
+	// As the spans don't overlap, their ends are sorted as well as their starts.
+	from := sort.Search(len(s), func(i int) bool { return !d.before(s[i].End(), window.Start()) })
+	windowed := Spans[T]{}
+	d.window(s, window, from, func(span Span[T]) {
+		windowed = append(windowed, span)
+	})
+	return windowed
+}
+
+// WindowClipped returns the spans in s which overlap the window in the same way as Window, but with any which extend
+// outside of the window cut to its bounds. Where a span is cut, the type of the window's end point is used, and where
+// a span and the window start or end at the same value, the tighter of their types is used, as by Intersection.
+// Spans which lie within the window are returned unchanged.
+func (d Domain[T]) WindowClipped(s Spans[T], window Span[T]) Spans[T] {
+	return d.Clip(d.Window(s, window), window)
+}
+
+// ClipHandlerFunc is used by ClipWithHandler to allow for custom functionality when a span is cut to fit within a
+// window. It is passed the span which has been cut, and the span representing the part of it within the window.
+type ClipHandlerFunc[T any] func(original, clipped Span[T]) Span[T]
+
+// ClipWithHandler returns a list of the spans in s cut to fit within the window, in the same order, leaving out any
+// which lie entirely outside of it. Where a span is cut, the type of the window's end point is used, and where a span
+// and the window start or end at the same value, the tighter of their types is used, as by Intersection. Unlike
+// WindowClipped, the spans don't need to be sorted. The provided handler is passed each span which is cut, and the
+// span representing the part of it within the window. Spans which lie within the window are returned unchanged.
+func (d Domain[T]) ClipWithHandler(s Spans[T], window Span[T], clipHandlerFunc ClipHandlerFunc[T]) Spans[T] {
+	clipped := Spans[T]{}
+	for _, span := range s {
+		switch {
+		case !d.Overlaps(span, window):
+			continue
+		case d.ContainsSpan(window, span):
+			clipped = append(clipped, span)
+		default:
+			clipped = append(clipped, clipHandlerFunc(span, d.Intersect(span, window)))
+		}
+	}
+	return clipped
+}
+
+// Clip returns a list of the spans in s cut to fit within the window, in the same order, leaving out any which lie
+// entirely outside of it. See ClipWithHandler.
+func (d Domain[T]) Clip(s Spans[T], window Span[T]) Spans[T] {
+	return d.ClipWithHandler(s, window, func(original, clipped Span[T]) Span[T] {
+		return clipped
+	})
+}
@@ -1,6 +1,7 @@
 package spaniel_test
 
 import (
+	"math/rand"
 	"reflect"
 	"sort"
 	"testing"
@@ -443,3 +444,112 @@ func TestIntersectionBetween(t *testing.T) {
 		expectEqual(t, after, expected)
 	})
 }
+
+func TestIntersectionBetweenWithHandler(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	spans, candidates := randomSpans(r, 300), randomSpans(r, 200)
+
+	type call struct {
+		candidate, span, intersection timespan.Span
+	}
+	var calls []call
+	after := spans.IntersectionBetweenWithHandler(candidates, func(intersectingEvent1, intersectingEvent2, intersectionSpan timespan.Span) timespan.Span {
+		calls = append(calls, call{intersectingEvent1, intersectingEvent2, intersectionSpan})
+		return intersectionSpan
+	})
+
+	// Compare each pair on its own, in the order of the candidates and then the spans.
+	var expectedCalls []call
+	expected := timespan.Spans{}
+	for _, candidate := range candidates {
+		for _, span := range spans {
+			for _, intersection := range (timespan.Spans{candidate, span}).Intersection() {
+				expectedCalls = append(expectedCalls, call{candidate, span, intersection})
+				expected = append(expected, intersection)
+			}
+		}
+	}
+
+	expectEqual(t, after, expected)
+	expectEqual(t, calls, expectedCalls)
+}
+
+func BenchmarkIntersectionBetween(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	spans, candidates := randomSpans(r, 5000), randomSpans(r, 5000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		spans.IntersectionBetween(candidates)
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+
+	t.Run("Should return nothing for no lists", func(t *testing.T) {
+		expectEqual(t, timespan.IntersectAll(), timespan.Spans{})
+	})
+
+	t.Run("Should return the union of a single list", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+		after := timespan.IntersectAll(timespan.Spans{a, b})
+		expectEqual(t, after, timespan.Spans{timespan.New(now, now.Add(2*time.Hour))})
+	})
+
+	t.Run("Should return the time covered by every list", func(t *testing.T) {
+		a := timespan.New(now, now.Add(3*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(4*time.Hour))
+		c := timespan.New(now.Add(2*time.Hour), now.Add(5*time.Hour))
+		after := timespan.IntersectAll(timespan.Spans{a}, timespan.Spans{b}, timespan.Spans{c})
+		expectEqual(t, after, timespan.Spans{timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))})
+	})
+
+	t.Run("Should return nothing if any list doesn't overlap", func(t *testing.T) {
+		a := timespan.New(now, now.Add(3*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(4*time.Hour))
+		c := timespan.New(now.Add(5*time.Hour), now.Add(6*time.Hour))
+		after := timespan.IntersectAll(timespan.Spans{a}, timespan.Spans{b}, timespan.Spans{c})
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should return nothing if any list is empty", func(t *testing.T) {
+		a := timespan.New(now, now.Add(3*time.Hour))
+		after := timespan.IntersectAll(timespan.Spans{a}, timespan.Spans{}, timespan.Spans{a})
+		expectEqual(t, after, timespan.Spans{})
+	})
+
+	t.Run("Should return several intersections", func(t *testing.T) {
+		a := timespan.New(now, now.Add(6*time.Hour))
+		b := timespan.New(now.Add(time.Hour), now.Add(2*time.Hour))
+		c := timespan.New(now.Add(3*time.Hour), now.Add(5*time.Hour))
+		d := timespan.New(now.Add(90*time.Minute), now.Add(4*time.Hour))
+		after := timespan.IntersectAll(timespan.Spans{a}, timespan.Spans{c, b}, timespan.Spans{d})
+		expectEqual(t, after, timespan.Spans{
+			timespan.New(now.Add(90*time.Minute), now.Add(2*time.Hour)),
+			timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour)),
+		})
+	})
+
+	t.Run("Should use the tightest endpoint types", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed)
+		b := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Closed)
+		c := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open)
+		after := timespan.IntersectAll(timespan.Spans{a}, timespan.Spans{b}, timespan.Spans{c})
+		expectEqual(t, after, timespan.Spans{timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Open)})
+	})
+}
+
+func TestIntersectAllWithHandler(t *testing.T) {
+	a := NewPropertyEvent(now, now.Add(3*time.Hour), []string{"machine1"})
+	b := NewPropertyEvent(now.Add(time.Hour), now.Add(4*time.Hour), []string{"machine2"})
+	c := NewPropertyEvent(now.Add(2*time.Hour), now.Add(5*time.Hour), []string{"machine3"})
+
+	var intersected []timespan.Spans
+	after := timespan.IntersectAllWithHandler(func(intersectingSpans timespan.Spans, intersectionSpan timespan.Span) timespan.Span {
+		intersected = append(intersected, intersectingSpans)
+		return intersectionSpan
+	}, timespan.Spans{a}, timespan.Spans{b}, timespan.Spans{c})
+
+	expectEqual(t, after, timespan.Spans{timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))})
+	expectEqual(t, intersected, []timespan.Spans{{a, b, c}})
+}
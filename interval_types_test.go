@@ -198,3 +198,183 @@ func TestTypedIntersection(t *testing.T) {
 		})
 	}
 }
+
+func TestContainsSpan(t *testing.T) {
+
+	for _, tt := range []struct {
+		name         string
+		outer, inner timespan.Span
+		expected     bool
+	}{
+		{
+			//  [----outer----)
+			//     [inner)
+			name:     "inside",
+			outer:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Open),
+			inner:    timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open),
+			expected: true,
+		},
+		{
+			//  [--outer--)
+			//  [--inner--)
+			name:     "same",
+			outer:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Open),
+			inner:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Open),
+			expected: true,
+		},
+		{
+			//  (--outer--]
+			//  [--inner--]
+			name:     "same times, inner includes start",
+			outer:    timespan.NewWithTypes(t1, t4, timespan.Open, timespan.Closed),
+			inner:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Closed),
+			expected: false,
+		},
+		{
+			//  [--outer--)
+			//  [--inner--]
+			name:     "same times, inner includes end",
+			outer:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Open),
+			inner:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Closed),
+			expected: false,
+		},
+		{
+			//  [--outer--]
+			//  (--inner--)
+			name:     "same times, inner excludes both",
+			outer:    timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Closed),
+			inner:    timespan.NewWithTypes(t1, t4, timespan.Open, timespan.Open),
+			expected: true,
+		},
+		{
+			//  [--outer--)
+			//       [--inner--)
+			name:     "overlapping",
+			outer:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			inner:    timespan.NewWithTypes(t2, t4, timespan.Closed, timespan.Open),
+			expected: false,
+		},
+		{
+			//  [--outer--)
+			//            [i]
+			name:     "instant at open end",
+			outer:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			inner:    timespan.NewInstant(t3),
+			expected: false,
+		},
+		{
+			//  [--outer--)
+			//  [i]
+			name:     "instant at closed start",
+			outer:    timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			inner:    timespan.NewInstant(t1),
+			expected: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if timespan.ContainsSpan(tt.outer, tt.inner) != tt.expected {
+				t.Errorf("expected ContainsSpan to be %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestContainsTime(t *testing.T) {
+	span := timespan.NewWithTypes(t1, t3, timespan.Open, timespan.Closed)
+
+	for _, tt := range []struct {
+		name     string
+		t        time.Time
+		expected bool
+	}{
+		{name: "before", t: t1.Add(-time.Second), expected: false},
+		{name: "at open start", t: t1, expected: false},
+		{name: "inside", t: t2, expected: true},
+		{name: "at closed end", t: t3, expected: true},
+		{name: "after", t: t4, expected: false},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if timespan.ContainsTime(span, tt.t) != tt.expected {
+				t.Errorf("expected ContainsTime to be %v", tt.expected)
+			}
+		})
+	}
+
+	if !timespan.ContainsTime(timespan.NewWithTypes(t1, t1, timespan.Open, timespan.Open), t1) {
+		t.Errorf("expected an instant to contain its time")
+	}
+}
+
+func TestEqual(t *testing.T) {
+
+	for _, tt := range []struct {
+		name     string
+		a, b     timespan.Span
+		expected bool
+	}{
+		{
+			name:     "same",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			expected: true,
+		},
+		{
+			name:     "same, different location",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1.In(time.FixedZone("UTC+1", 3600)), t2, timespan.Closed, timespan.Open),
+			expected: true,
+		},
+		{
+			name:     "different start type",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Open),
+			expected: false,
+		},
+		{
+			name:     "different end type",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Closed),
+			expected: false,
+		},
+		{
+			name:     "different end",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			expected: false,
+		},
+		{
+			name:     "instants with different types",
+			a:        timespan.NewWithTypes(t1, t1, timespan.Closed, timespan.Open),
+			b:        timespan.NewInstant(t1),
+			expected: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if timespan.Equal(tt.a, tt.b) != tt.expected {
+				t.Errorf("in order, expected Equal to be %v", tt.expected)
+			}
+			if timespan.Equal(tt.b, tt.a) != tt.expected {
+				t.Errorf("reversed, expected Equal to be %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestOverlapsAndContiguous(t *testing.T) {
+	a := timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open)
+	b := timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open)
+	c := timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open)
+
+	if timespan.Overlaps(a, b) || !timespan.Contiguous(a, b) {
+		t.Errorf("expected consecutive spans to be contiguous, not overlapping")
+	}
+	if !timespan.Overlaps(a, c) || timespan.Contiguous(a, c) {
+		t.Errorf("expected nested spans to overlap, not be contiguous")
+	}
+}
@@ -0,0 +1,76 @@
+package spaniel
+
+import (
+	"iter"
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// All returns an iterator over the contained spans, in order.
+func (s Spans) All() iter.Seq[Span] {
+	return spans(s).All()
+}
+
+// Collect returns a list of the spans produced by seq.
+func Collect(seq iter.Seq[Span]) Spans {
+	return Spans(interval.Collect(seq))
+}
+
+// UnionWithHandlerSeq returns an iterator over the spans returned by UnionWithHandler. Each merged span is produced
+// as soon as no later span can be merged with it, so stopping early avoids merging the rest of the spans.
+func (s Spans) UnionWithHandlerSeq(unionHandlerFunc UnionHandlerFunc) iter.Seq[Span] {
+	return timeDomain.UnionWithHandlerSeq(spans(s), interval.UnionHandlerFunc[time.Time](unionHandlerFunc))
+}
+
+// UnionSeq returns an iterator over the spans returned by Union. See UnionWithHandlerSeq.
+func (s Spans) UnionSeq() iter.Seq[Span] {
+	return timeDomain.UnionSeq(spans(s))
+}
+
+// UnionSorted returns an iterator which merges the spans produced by seq, which must be sorted by their start times,
+// in the same way as UnionStream. Each merged span is produced with a nil error as soon as no later span can be merged
+// with it. If a span starts before the span preceding it, ErrOutOfOrder is produced with a nil span, and the iterator
+// stops.
+func UnionSorted(seq iter.Seq[Span]) iter.Seq2[Span, error] {
+	return timeDomain.UnionSorted(seq)
+}
+
+// IntersectionWithHandlerSeq returns an iterator over the spans returned by IntersectionWithHandler, which finds each
+// overlap only as it is needed.
+func (s Spans) IntersectionWithHandlerSeq(intersectHandlerFunc IntersectionHandlerFunc) iter.Seq[Span] {
+	return timeDomain.IntersectionWithHandlerSeq(spans(s), interval.IntersectionHandlerFunc[time.Time](intersectHandlerFunc))
+}
+
+// IntersectionSeq returns an iterator over the spans returned by Intersection. See IntersectionWithHandlerSeq.
+func (s Spans) IntersectionSeq() iter.Seq[Span] {
+	return timeDomain.IntersectionSeq(spans(s))
+}
+
+// IntersectionBetweenWithHandlerSeq returns an iterator over the spans returned by IntersectionBetweenWithHandler,
+// which finds each overlap only as it is needed.
+func (s Spans) IntersectionBetweenWithHandlerSeq(candidates Spans, intersectHandlerFunc IntersectionHandlerFunc) iter.Seq[Span] {
+	return timeDomain.IntersectionBetweenWithHandlerSeq(spans(s), spans(candidates), interval.IntersectionHandlerFunc[time.Time](intersectHandlerFunc))
+}
+
+// IntersectionBetweenSeq returns an iterator over the spans returned by IntersectionBetween. See
+// IntersectionBetweenWithHandlerSeq.
+func (s Spans) IntersectionBetweenSeq(candidates Spans) iter.Seq[Span] {
+	return timeDomain.IntersectionBetweenSeq(spans(s), spans(candidates))
+}
+
+// DifferenceWithHandlerSeq returns an iterator over the spans returned by DifferenceWithHandler, which cuts each span
+// only as it is needed.
+func (s Spans) DifferenceWithHandlerSeq(other Spans, differenceHandlerFunc DifferenceHandlerFunc) iter.Seq[Span] {
+	return timeDomain.DifferenceWithHandlerSeq(spans(s), spans(other), interval.DifferenceHandlerFunc[time.Time](differenceHandlerFunc))
+}
+
+// DifferenceSeq returns an iterator over the spans returned by Difference. See DifferenceWithHandlerSeq.
+func (s Spans) DifferenceSeq(other Spans) iter.Seq[Span] {
+	return timeDomain.DifferenceSeq(spans(s), spans(other))
+}
+
+// ComplementSeq returns an iterator over the spans returned by Complement.
+func (s Spans) ComplementSeq(window Span) iter.Seq[Span] {
+	return timeDomain.ComplementSeq(spans(s), window)
+}
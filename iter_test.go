@@ -0,0 +1,106 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestSeq(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	spans := randomSpans(r, 200)
+	others := randomSpans(r, 50)
+
+	t.Run("Should produce the same spans as the list functions", func(t *testing.T) {
+		expectEqual(t, timespan.Collect(spans.All()), spans)
+		expectEqual(t, timespan.Collect(spans.UnionSeq()), spans.Union())
+		expectEqual(t, timespan.Collect(spans.IntersectionSeq()), spans.Intersection())
+		expectEqual(t, timespan.Collect(spans.IntersectionBetweenSeq(others)), spans.IntersectionBetween(others))
+		expectEqual(t, timespan.Collect(spans.DifferenceSeq(others)), spans.Difference(others))
+
+		window := timespan.New(now, now.Add(24*time.Hour))
+		expectEqual(t, timespan.Collect(others.ComplementSeq(window)), others.Complement(window))
+	})
+
+	t.Run("Should produce nothing for an empty list", func(t *testing.T) {
+		expectEqual(t, timespan.Collect(timespan.Spans{}.UnionSeq()), timespan.Spans{})
+		expectEqual(t, timespan.Collect(timespan.Spans{}.IntersectionSeq()), timespan.Spans{})
+	})
+
+	t.Run("Should stop early", func(t *testing.T) {
+		sparse := randomSpans(r, 20)
+		for _, seq := range []func(yield func(timespan.Span) bool){
+			sparse.UnionSeq(),
+			spans.IntersectionSeq(),
+			spans.IntersectionBetweenSeq(others),
+			spans.DifferenceSeq(others),
+		} {
+			count := 0
+			for range seq {
+				count++
+				if count == 3 {
+					break
+				}
+			}
+			expectEqual(t, count, 3)
+		}
+	})
+
+	t.Run("Should call the handler lazily", func(t *testing.T) {
+		calls := 0
+		seq := spans.IntersectionWithHandlerSeq(func(intersectingEvent1, intersectingEvent2, intersectionSpan timespan.Span) timespan.Span {
+			calls++
+			return intersectionSpan
+		})
+		for range seq {
+			break
+		}
+		expectEqual(t, calls, 1)
+
+		calls = 0
+		seq = spans.IntersectionBetweenWithHandlerSeq(others, func(intersectingEvent1, intersectingEvent2, intersectionSpan timespan.Span) timespan.Span {
+			calls++
+			return intersectionSpan
+		})
+		for range seq {
+			break
+		}
+		expectEqual(t, calls, 1)
+	})
+}
+
+func TestUnionSorted(t *testing.T) {
+
+	t.Run("Should merge sorted spans", func(t *testing.T) {
+		spans := randomSpans(rand.New(rand.NewSource(1)), 200)
+		sort.Stable(timespan.ByStart(spans))
+
+		merged := timespan.Spans{}
+		for span, err := range timespan.UnionSorted(slices.Values(spans)) {
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			merged = append(merged, span)
+		}
+		expectEqual(t, merged, spans.Union())
+	})
+
+	t.Run("Should produce an error for spans out of order", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(30*time.Minute), now.Add(90*time.Minute))
+
+		var merged timespan.Spans
+		var errs []error
+		for span, err := range timespan.UnionSorted(timespan.Spans{a, b, c}.All()) {
+			merged = append(merged, span)
+			errs = append(errs, err)
+		}
+		expectEqual(t, merged, timespan.Spans{a, nil})
+		expectEqual(t, errs, []error{nil, timespan.ErrOutOfOrder})
+	})
+}
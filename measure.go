@@ -0,0 +1,41 @@
+package spaniel
+
+import (
+	"time"
+)
+
+// Duration returns the amount of time covered by the span. This is zero for an instant, as a single point in time has
+// no length, and for a span which ends before it starts. The types of the end points make no difference, so [1,2)
+// and [1,2] both cover the same amount of time.
+func Duration(a Span) time.Duration {
+	if !a.Start().Before(a.End()) {
+		return 0
+	}
+	return a.End().Sub(a.Start())
+}
+
+// Measure returns the total amount of time covered by the contained spans. The spans are merged as by Union first, so
+// that time covered by more than one of them is only counted once.
+// For example, given a list [A,B] where A and B overlap, the duration of the union of A and B would be returned,
+// rather than the sum of their durations. Instants have no duration, so they make no difference to the total; see
+// CountInstants.
+func (s Spans) Measure() time.Duration {
+	var total time.Duration
+	for _, span := range s.Union() {
+		total += Duration(span)
+	}
+	return total
+}
+
+// CountInstants returns the number of separate instants covered by the contained spans, which are not accounted for
+// by Measure. The spans are merged as by Union first, so an instant which lies within another span is not counted,
+// and the same instant appearing more than once is counted once. Empty spans, as reported by IsEmpty, are ignored.
+func (s Spans) CountInstants() int {
+	count := 0
+	for _, span := range s.Normalize().Union() {
+		if IsInstant(span) {
+			count++
+		}
+	}
+	return count
+}
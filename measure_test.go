@@ -0,0 +1,49 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestDuration(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		span     timespan.Span
+		expected time.Duration
+	}{
+		{"Should return the length of a span", timespan.New(now, now.Add(time.Hour)), time.Hour},
+		{"Should ignore the types of the end points", timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Closed), time.Hour},
+		{"Should return zero for an instant", timespan.NewInstant(now), 0},
+		{"Should return zero for a reversed span", timespan.New(now.Add(time.Hour), now), 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			expectEqual(t, timespan.Duration(tt.span), tt.expected)
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	a := timespan.New(now, now.Add(time.Hour))
+	b := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+	c := timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour))
+	d := timespan.NewInstant(now.Add(5 * time.Hour))
+	e := timespan.NewInstant(now.Add(90 * time.Minute))
+	f := timespan.NewWithTypes(now.Add(6*time.Hour), now.Add(6*time.Hour), timespan.Closed, timespan.Open)
+
+	t.Run("Should return zero for no spans", func(t *testing.T) {
+		expectEqual(t, timespan.Spans{}.Measure(), time.Duration(0))
+		expectEqual(t, timespan.Spans{}.CountInstants(), 0)
+	})
+
+	t.Run("Should not count overlapping time twice", func(t *testing.T) {
+		expectEqual(t, timespan.Spans{a, b, c}.Measure(), 3*time.Hour)
+	})
+
+	t.Run("Should count instants separately", func(t *testing.T) {
+		spans := timespan.Spans{a, b, c, d, d, e, f}
+		expectEqual(t, spans.Measure(), 3*time.Hour)
+		expectEqual(t, spans.CountInstants(), 1)
+	})
+}
@@ -0,0 +1,33 @@
+package spaniel
+
+import (
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// MergeSortedHandlerFunc is used by MergeSortedWithHandler to allow for custom functionality when spans from the
+// lists are merged. It is passed the indices of the lists which the spans merged came from, in ascending order, and
+// the span which will result from the merge.
+type MergeSortedHandlerFunc func(sources []int, mergeSpan Span) Span
+
+// MergeSortedWithHandler returns the union of several lists of spans, each of which must already be sorted by start
+// time, as returned by Union. The result is the same as joining the lists together and calling UnionWithHandler, but
+// rather than sorting all of the spans again, the next span from each list is kept in a heap, so that merging n spans
+// from k lists takes O(n log k) time.
+// For example, given lists [A,B] and [C] where A and C overlap, a list [D,B] would be returned, with the span D
+// spanning both A and C. The provided handler is called for every span returned, including spans which weren't merged
+// with any other, and is passed the indices of the lists the span drew from, along with the span itself, so that D
+// would be passed [0,1], and B would be passed [0]. If a span starts before the span preceding it in the same list,
+// ErrOutOfOrder is returned.
+func MergeSortedWithHandler(mergeSortedHandlerFunc MergeSortedHandlerFunc, lists ...Spans) (Spans, error) {
+	merged, err := timeDomain.MergeSortedWithHandler(interval.MergeSortedHandlerFunc[time.Time](mergeSortedHandlerFunc), genericLists(lists)...)
+	return Spans(merged), err
+}
+
+// MergeSorted returns the union of several lists of spans, each of which must already be sorted by start time, as
+// returned by Union. See MergeSortedWithHandler.
+func MergeSorted(lists ...Spans) (Spans, error) {
+	merged, err := timeDomain.MergeSorted(genericLists(lists)...)
+	return Spans(merged), err
+}
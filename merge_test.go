@@ -0,0 +1,73 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestMergeSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var lists []timespan.Spans
+	var all timespan.Spans
+	for i := 0; i < 20; i++ {
+		list := randomSpans(r, 3).Union()
+		lists = append(lists, list)
+		all = append(all, list...)
+	}
+
+	t.Run("Should return the same spans as Union", func(t *testing.T) {
+		merged, err := timespan.MergeSorted(lists...)
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, all.Union())
+	})
+
+	t.Run("Should return nothing for no spans", func(t *testing.T) {
+		merged, err := timespan.MergeSorted()
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, timespan.Spans{})
+
+		merged, err = timespan.MergeSorted(timespan.Spans{}, nil)
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, timespan.Spans{})
+	})
+
+	t.Run("Should pass the lists each span drew from to the handler", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(30*time.Minute), now.Add(90*time.Minute))
+		d := timespan.New(now.Add(80*time.Minute), now.Add(100*time.Minute))
+
+		var sources [][]int
+		merged, err := timespan.MergeSortedWithHandler(func(s []int, mergeSpan timespan.Span) timespan.Span {
+			sources = append(sources, s)
+			return mergeSpan
+		}, timespan.Spans{a, b}, timespan.Spans{}, timespan.Spans{d}, timespan.Spans{c})
+
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, timespan.Spans{timespan.New(now, now.Add(100*time.Minute)), b})
+		expectEqual(t, sources, [][]int{{0, 2, 3}, {0}})
+	})
+
+	t.Run("Should return an error for a list out of order", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		merged, err := timespan.MergeSorted(timespan.Spans{a}, timespan.Spans{b, a})
+		expectEqual(t, err, timespan.ErrOutOfOrder)
+		expectEqual(t, merged, timespan.Spans(nil))
+	})
+}
+
+func BenchmarkMergeSorted(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	var lists []timespan.Spans
+	for i := 0; i < 100; i++ {
+		lists = append(lists, randomSpans(r, 1000).Union())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = timespan.MergeSorted(lists...)
+	}
+}
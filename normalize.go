@@ -0,0 +1,24 @@
+package spaniel
+
+// IsEmpty returns true if the span doesn't include any time at all. This is the case if it ends before it starts, or
+// if it starts and ends at the same time but isn't Closed at both ends, such as (1,1] or [1,1). Note that IsInstant
+// returns true for any span which starts and ends at the same time, and the other functions in this package treat
+// such spans as Closed instants; use Normalize to remove empty spans before operating on them if that isn't wanted.
+func IsEmpty(a Span) bool {
+	return timeDomain.IsEmpty(a)
+}
+
+// Returns true if a comes before b when sorted by start points and then end points.
+func lessPoints(a, b Span) bool {
+	return timeDomain.CompareSpans(a, b) < 0
+}
+
+// Normalize returns a list of the contained spans in a canonical form, so that the results of the other functions in
+// this package are consistent regardless of how the spans were constructed. Empty spans, as reported by IsEmpty, are
+// removed, so the only spans left which start and end at the same time are instants which are Closed at both ends.
+// The remaining spans are sorted by their start points and then by their end points, with a Closed start coming
+// before an Open start at the same time, and an Open end before a Closed end, so that an instant comes before any
+// other span starting at the same time. Spans which are otherwise equal keep their original order.
+func (s Spans) Normalize() Spans {
+	return Spans(timeDomain.Normalize(spans(s)))
+}
@@ -0,0 +1,81 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestIsEmpty(t *testing.T) {
+
+	for _, tt := range []struct {
+		name     string
+		span     timespan.Span
+		expected bool
+	}{
+		{name: "span", span: timespan.New(t1, t2), expected: false},
+		{name: "open span", span: timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Open), expected: false},
+		{name: "instant", span: timespan.NewInstant(t1), expected: false},
+		{name: "closed open instant", span: timespan.NewWithTypes(t1, t1, timespan.Closed, timespan.Open), expected: true},
+		{name: "open closed instant", span: timespan.NewWithTypes(t1, t1, timespan.Open, timespan.Closed), expected: true},
+		{name: "open instant", span: timespan.NewWithTypes(t1, t1, timespan.Open, timespan.Open), expected: true},
+		{name: "reversed", span: timespan.New(t2, t1), expected: true},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if timespan.IsEmpty(tt.span) != tt.expected {
+				t.Errorf("expected IsEmpty to be %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+
+	t.Run("Should return nothing for no timespans", func(t *testing.T) {
+		expectEqual(t, timespan.Spans{}.Normalize(), timespan.Spans{})
+	})
+
+	t.Run("Should remove empty timespans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := NewEvent(now.Add(30*time.Minute), now.Add(30*time.Minute))
+		c := timespan.New(now.Add(3*time.Hour), now.Add(2*time.Hour))
+		expectEqual(t, timespan.Spans{a, b, c}.Normalize(), timespan.Spans{a})
+	})
+
+	t.Run("Should keep closed instants", func(t *testing.T) {
+		a := timespan.NewInstant(now)
+		expectEqual(t, timespan.Spans{a}.Normalize(), timespan.Spans{a})
+	})
+
+	t.Run("Should sort timespans by start then end", func(t *testing.T) {
+		a := timespan.New(now, now.Add(2*time.Hour))
+		b := timespan.New(now, now.Add(time.Hour))
+		c := timespan.NewInstant(now)
+		d := timespan.New(now.Add(-time.Hour), now.Add(3*time.Hour))
+		expectEqual(t, timespan.Spans{a, b, c, d}.Normalize(), timespan.Spans{d, c, b, a})
+	})
+
+	t.Run("Should sort by endpoint type at the same time", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Closed)
+		b := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed)
+		c := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open)
+		expectEqual(t, timespan.Spans{a, b, c}.Normalize(), timespan.Spans{c, b, a})
+	})
+
+	t.Run("Should keep the order of equal timespans", func(t *testing.T) {
+		a := NewPropertyEvent(now, now.Add(time.Hour), []string{"a"})
+		b := NewPropertyEvent(now, now.Add(time.Hour), []string{"b"})
+		expectEqual(t, timespan.Spans{a, b}.Normalize(), timespan.Spans{a, b})
+		expectEqual(t, timespan.Spans{b, a}.Normalize(), timespan.Spans{b, a})
+	})
+
+	t.Run("Should not merge a normalized empty timespan into a union", func(t *testing.T) {
+		a := NewEvent(now, now.Add(time.Hour))
+		b := NewEvent(now.Add(time.Hour), now.Add(time.Hour))
+		b.SetStartType(timespan.Open)
+		expectEqual(t, timespan.Spans{a, b}.Normalize().Union(), timespan.Spans{a})
+	})
+}
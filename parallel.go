@@ -0,0 +1,16 @@
+package spaniel
+
+// UnionParallel returns the same list of Spans as Union, splitting the work of sorting and merging the spans between
+// the given number of goroutines, or one for each processor if workers is zero or less. The sorted spans are split
+// into a chunk of time for each worker, and the merged chunks are joined together where they meet. This is only
+// worthwhile for long lists of spans, such as hundreds of thousands.
+func (s Spans) UnionParallel(workers int) Spans {
+	return Spans(timeDomain.UnionParallel(spans(s), workers))
+}
+
+// IntersectionParallel returns the same list of Spans as Intersection, splitting the work of sorting the spans and
+// finding their overlaps between the given number of goroutines, or one for each processor if workers is zero or less.
+// This is only worthwhile for long lists of spans, such as hundreds of thousands.
+func (s Spans) IntersectionParallel(workers int) Spans {
+	return Spans(timeDomain.IntersectionParallel(spans(s), workers))
+}
@@ -0,0 +1,65 @@
+package spaniel_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sparse := timespan.Spans{}
+	for i := 0; i < 500; i++ {
+		start := now.Add(time.Duration(r.Intn(100000)) * time.Minute)
+		sparse = append(sparse, timespan.New(start, start.Add(time.Duration(1+r.Intn(120))*time.Minute)))
+	}
+	dense := randomSpans(r, 500)
+	duplicated := append(append(timespan.Spans{}, dense[:100]...), dense[:100]...)
+
+	for _, tt := range []struct {
+		name  string
+		spans timespan.Spans
+	}{
+		{"sparse", sparse},
+		{"dense", dense},
+		{"duplicated", duplicated},
+		{"single", dense[:1]},
+		{"empty", timespan.Spans{}},
+	} {
+		for _, workers := range []int{0, 1, 2, 3, 7, 1000} {
+			t.Run(fmt.Sprintf("Should return the same union for %s spans with %d workers", tt.name, workers), func(t *testing.T) {
+				expectEqual(t, tt.spans.UnionParallel(workers), tt.spans.Union())
+			})
+			t.Run(fmt.Sprintf("Should return the same intersections for %s spans with %d workers", tt.name, workers), func(t *testing.T) {
+				expectEqual(t, tt.spans.IntersectionParallel(workers), tt.spans.Intersection())
+			})
+		}
+	}
+
+	t.Run("Should not merge across a gap left by an Open start", func(t *testing.T) {
+		// The sequential union doesn't merge [0,1h) with [1h,2h], as it is compared with (1h,2h] instead.
+		spans := timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Open, timespan.Closed),
+			timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Closed, timespan.Closed),
+		}
+		expectEqual(t, spans.UnionParallel(3), spans.Union())
+	})
+}
+
+func BenchmarkUnion(b *testing.B) {
+	spans := randomSpans(rand.New(rand.NewSource(1)), 1000000)
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			spans.Union()
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			spans.UnionParallel(0)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package spaniel
+
+import (
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// Relation represents one of the thirteen relations of Allen's interval algebra, which describe how two spans are
+// ordered with respect to one another.
+type Relation = interval.Relation
+
+const (
+	// RelationBefore means that the first span finishes before the second starts, with a gap between them.
+	RelationBefore = interval.RelationBefore
+	// RelationMeets means that the first span finishes where the second starts, with no gap and no overlap.
+	RelationMeets = interval.RelationMeets
+	// RelationOverlaps means that the first span starts before the second, and finishes within it.
+	RelationOverlaps = interval.RelationOverlaps
+	// RelationStarts means that the spans start together, and the first finishes before the second.
+	RelationStarts = interval.RelationStarts
+	// RelationDuring means that the first span starts after and finishes before the second.
+	RelationDuring = interval.RelationDuring
+	// RelationFinishes means that the spans finish together, and the first starts after the second.
+	RelationFinishes = interval.RelationFinishes
+	// RelationEquals means that the spans start and finish together.
+	RelationEquals = interval.RelationEquals
+	// RelationFinishedBy is the inverse of RelationFinishes.
+	RelationFinishedBy = interval.RelationFinishedBy
+	// RelationContains is the inverse of RelationDuring.
+	RelationContains = interval.RelationContains
+	// RelationStartedBy is the inverse of RelationStarts.
+	RelationStartedBy = interval.RelationStartedBy
+	// RelationOverlappedBy is the inverse of RelationOverlaps.
+	RelationOverlappedBy = interval.RelationOverlappedBy
+	// RelationMetBy is the inverse of RelationMeets.
+	RelationMetBy = interval.RelationMetBy
+	// RelationAfter is the inverse of RelationBefore.
+	RelationAfter = interval.RelationAfter
+)
+
+// Relate returns the relation of span a to span b, taking the types of their end points into account in the same way
+// as Overlaps and Contiguous.
+// For example, [1,2) meets [2,3], as they are contiguous, whereas [1,2] overlaps [2,3], and [1,2) is before (2,3].
+// A start which is Closed comes before an Open start at the same time, and an end which is Open comes before a Closed
+// end at the same time, so [1,3) starts [1,3], and (1,3] finishes [1,3].
+func Relate(a, b Span) Relation {
+	return timeDomain.Relate(a, b)
+}
+
+// Related returns the list of contained spans which have one of the given relations to the reference span, as
+// returned by Relate(span, reference).
+// For example, calling Related with RelationDuring returns the spans which lie within the reference span.
+func (s Spans) Related(reference Span, relations ...Relation) Spans {
+	return Spans(timeDomain.Related(spans(s), reference, relations...))
+}
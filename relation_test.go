@@ -0,0 +1,162 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestRelate(t *testing.T) {
+
+	for _, tt := range []struct {
+		name     string
+		a, b     timespan.Span
+		expected timespan.Relation
+	}{
+		{
+			//  [-a-)
+			//         [-b-)
+			name:     "before",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t3, t4, timespan.Closed, timespan.Open),
+			expected: timespan.RelationBefore,
+		},
+		{
+			//  [-a-)
+			//      (-b-)
+			name:     "before, excluding the shared point",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t2, t3, timespan.Open, timespan.Open),
+			expected: timespan.RelationBefore,
+		},
+		{
+			//  [-a-)
+			//      [-b-)
+			name:     "meets",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open),
+			expected: timespan.RelationMeets,
+		},
+		{
+			//  [a]
+			//  (-b-)
+			name:     "instant meets",
+			a:        timespan.NewInstant(t1),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Open, timespan.Open),
+			expected: timespan.RelationMeets,
+		},
+		{
+			//  [-a-]
+			//      [-b-)
+			name:     "overlaps at a single point",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Closed),
+			b:        timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open),
+			expected: timespan.RelationOverlaps,
+		},
+		{
+			//  [---a---)
+			//      [---b---)
+			name:     "overlaps",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t2, t4, timespan.Closed, timespan.Open),
+			expected: timespan.RelationOverlaps,
+		},
+		{
+			//  [-a-)
+			//  [---b---)
+			name:     "starts",
+			a:        timespan.NewWithTypes(t1, t2, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			expected: timespan.RelationStarts,
+		},
+		{
+			//  [---a---)
+			//  [---b---]
+			name:     "starts, excluding the end",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			expected: timespan.RelationStarts,
+		},
+		{
+			//    [a)
+			//  [--b--)
+			name:     "during",
+			a:        timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t4, timespan.Closed, timespan.Open),
+			expected: timespan.RelationDuring,
+		},
+		{
+			//  (---a---)
+			//  [---b---]
+			name:     "during, excluding both ends",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Open, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Closed),
+			expected: timespan.RelationDuring,
+		},
+		{
+			//      [-a-)
+			//  [---b---)
+			name:     "finishes",
+			a:        timespan.NewWithTypes(t2, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			expected: timespan.RelationFinishes,
+		},
+		{
+			//  [---a---)
+			//  [---b---)
+			name:     "equals",
+			a:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			b:        timespan.NewWithTypes(t1, t3, timespan.Closed, timespan.Open),
+			expected: timespan.RelationEquals,
+		},
+		{
+			//  [a]
+			//  [b]
+			name:     "equal instants",
+			a:        timespan.NewInstant(t1),
+			b:        timespan.NewInstant(t1),
+			expected: timespan.RelationEquals,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if r := timespan.Relate(tt.a, tt.b); r != tt.expected {
+				t.Errorf("in order, expected %v, got %v", tt.expected, r)
+			}
+			if r := timespan.Relate(tt.b, tt.a); r != tt.expected.Inverse() {
+				t.Errorf("reversed, expected %v, got %v", tt.expected.Inverse(), r)
+			}
+		})
+	}
+}
+
+func TestRelationString(t *testing.T) {
+	expectEqual(t, timespan.RelationBefore.String(), "before")
+	expectEqual(t, timespan.RelationOverlappedBy.String(), "overlapped by")
+	expectEqual(t, timespan.RelationAfter.Inverse(), timespan.RelationBefore)
+	expectEqual(t, timespan.RelationEquals.Inverse(), timespan.RelationEquals)
+	expectEqual(t, timespan.Relation(-1).String(), "unknown")
+}
+
+func TestRelated(t *testing.T) {
+	reference := timespan.New(now, now.Add(2*time.Hour))
+	a := timespan.New(now.Add(-time.Hour), now)
+	b := timespan.New(now.Add(30*time.Minute), now.Add(time.Hour))
+	c := timespan.New(now, now.Add(time.Hour))
+	d := timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour))
+	spans := timespan.Spans{a, b, c, d}
+
+	t.Run("Should return the spans with the given relation", func(t *testing.T) {
+		expectEqual(t, spans.Related(reference, timespan.RelationDuring), timespan.Spans{b})
+	})
+
+	t.Run("Should return the spans with any of the given relations", func(t *testing.T) {
+		expectEqual(t, spans.Related(reference, timespan.RelationMeets, timespan.RelationAfter, timespan.RelationStarts), timespan.Spans{a, c, d})
+	})
+
+	t.Run("Should return nothing if no relations are given", func(t *testing.T) {
+		expectEqual(t, spans.Related(reference), timespan.Spans{})
+	})
+}
@@ -0,0 +1,31 @@
+package rrule_test
+
+import (
+	"fmt"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+	"github.com/senseyeio/spaniel/rrule"
+)
+
+func ExampleNew() {
+	london, _ := time.LoadLocation("Europe/London")
+
+	// A two hour maintenance window at 02:00 on the first Sunday of each month, apart from January
+	dtstart := time.Date(2021, time.January, 3, 2, 0, 0, 0, london)
+	schedule, err := rrule.New(dtstart, 2*time.Hour,
+		"RRULE:FREQ=MONTHLY;BYDAY=1SU",
+		"EXDATE;TZID=Europe/London:20210103T020000",
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	window := timespan.New(time.Date(2021, time.January, 1, 0, 0, 0, 0, london), time.Date(2021, time.May, 1, 0, 0, 0, 0, london))
+	for _, span := range schedule.Expand(window) {
+		fmt.Println(span.Start().In(london), "->", span.End().In(london))
+	}
+	// Output: 2021-02-07 02:00:00 +0000 GMT -> 2021-02-07 04:00:00 +0000 GMT
+	// 2021-03-07 02:00:00 +0000 GMT -> 2021-03-07 04:00:00 +0000 GMT
+	// 2021-04-04 02:00:00 +0100 BST -> 2021-04-04 04:00:00 +0100 BST
+}
@@ -0,0 +1,213 @@
+// Package rrule expands iCalendar recurrence rules, as defined by RFC 5545, into spans of time, so that schedules such
+// as maintenance windows or shift patterns can be worked with using spaniel.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senseyeio/spaniel"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// OccurrenceHandlerFunc is used by ExpandWithHandler to allow for custom functionality when an occurrence is found.
+// It is passed the span covering the occurrence, and returns the span to use in its place.
+type OccurrenceHandlerFunc func(occurrence spaniel.Span) spaniel.Span
+
+// Schedule is a set of recurring occurrences which each last the same length of time, as described by the DTSTART,
+// RRULE, RDATE and EXDATE properties of an iCalendar event.
+type Schedule struct {
+	dtstart  time.Time
+	duration time.Duration
+	rules    []*rule
+	rdates   []time.Time
+	exdates  []time.Time
+}
+
+// New returns the Schedule of occurrences lasting duration, starting at dtstart and recurring as described by the
+// given RRULE, RDATE and EXDATE properties, such as "RRULE:FREQ=WEEKLY;BYDAY=TU". A rule may also be given without the
+// RRULE: prefix, and a string may hold several properties on separate lines.
+// The occurrences are found on the clock in the location of dtstart, so that an occurrence at 09:00 stays at 09:00
+// when the clocks change. Times in RDATE and EXDATE are in UTC if they end in Z, in the location of a TZID parameter
+// if they have one, or otherwise in the location of dtstart. Dates without times are taken to be at the time of day of
+// dtstart. Times are only precise to the second, so dtstart is truncated to the second.
+func New(dtstart time.Time, duration time.Duration, properties ...string) (*Schedule, error) {
+	if duration < 0 {
+		return nil, errors.New("duration is negative")
+	}
+	s := &Schedule{dtstart: dtstart.Truncate(time.Second), duration: duration}
+	for _, line := range unfold(properties) {
+		if err := s.parseProperty(line); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Splits the properties into lines, joining lines which RFC 5545 folds by starting them with a space or tab back on
+// to the line before them.
+func unfold(properties []string) []string {
+	lines := []string{}
+	for _, property := range properties {
+		for _, line := range strings.Split(strings.ReplaceAll(property, "\r\n", "\n"), "\n") {
+			if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+				lines[len(lines)-1] += line[1:]
+				continue
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// Parses a line holding an RRULE, RDATE or EXDATE property, adding it to the schedule.
+func (s *Schedule) parseProperty(line string) error {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		head, value = "RRULE", line
+	}
+	params := strings.Split(head, ";")
+	name := strings.ToUpper(params[0])
+
+	switch name {
+	case "RRULE":
+		r, err := parseRule(value, s.dtstart)
+		if err != nil {
+			return fmt.Errorf("invalid RRULE %q: %w", value, err)
+		}
+		s.rules = append(s.rules, r)
+	case "RDATE", "EXDATE":
+		times, err := s.parseTimes(value, params[1:])
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+		if name == "RDATE" {
+			s.rdates = append(s.rdates, times...)
+		} else {
+			s.exdates = append(s.exdates, times...)
+		}
+	default:
+		return fmt.Errorf("unsupported property %s", name)
+	}
+	return nil
+}
+
+// Parses the comma separated list of times of an RDATE or EXDATE property, given its parameters.
+func (s *Schedule) parseTimes(value string, params []string) ([]time.Time, error) {
+	loc := s.dtstart.Location()
+	for _, param := range params {
+		name, v, _ := strings.Cut(param, "=")
+		switch strings.ToUpper(name) {
+		case "TZID":
+			var err error
+			if loc, err = time.LoadLocation(strings.Trim(v, `"`)); err != nil {
+				return nil, err
+			}
+		case "VALUE":
+			if strings.EqualFold(v, "PERIOD") {
+				return nil, errors.New("PERIOD values are not supported")
+			}
+		}
+	}
+
+	times := []time.Time{}
+	for _, v := range strings.Split(value, ",") {
+		if d, err := time.Parse(dateLayout, v); err == nil {
+			hour, minute, second := s.dtstart.In(loc).Clock()
+			times = append(times, inLocation(d.Add(time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute+
+				time.Duration(second)*time.Second), loc))
+			continue
+		}
+		t, err := parseDateTime(v, loc)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// Parses a date and time, which is in UTC if it ends in Z, or otherwise on the clock in loc.
+func parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	if strings.HasSuffix(s, "Z") {
+		return time.Parse(dateTimeLayout+"Z", s)
+	}
+	wall, err := time.Parse(dateTimeLayout, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return inLocation(wall, loc), nil
+}
+
+// ExpandWithHandler returns a list of Spans covering each occurrence of the schedule which overlaps the window, in
+// order of their start. Each occurrence is [) unless its duration is zero, in which case it is an instant, as created
+// by spaniel.New. The DTSTART is always the first occurrence, and further occurrences are those of each RRULE and
+// RDATE, with any starting at the time of an EXDATE left out. Occurrences which start at the same time are only
+// returned once, but those which overlap are not merged. The provided handler is passed the span covering each
+// occurrence returned, and the span it returns is used in its place.
+func (s *Schedule) ExpandWithHandler(window spaniel.Span, occurrenceHandlerFunc OccurrenceHandlerFunc) spaniel.Spans {
+	// Occurrences starting up to the duration before the window can still overlap it.
+	from, to := window.Start().Add(-s.duration), window.End()
+
+	starts := []time.Time{}
+	for _, t := range append([]time.Time{s.dtstart}, s.rdates...) {
+		if !t.Before(from) && !t.After(to) {
+			starts = append(starts, t)
+		}
+	}
+	for _, r := range s.rules {
+		starts = append(starts, r.occurrences(s.dtstart, from, to)...)
+	}
+	slices.SortFunc(starts, time.Time.Compare)
+	starts = slices.CompactFunc(starts, time.Time.Equal)
+
+	expanded := spaniel.Spans{}
+	for _, start := range starts {
+		if slices.ContainsFunc(s.exdates, start.Equal) {
+			continue
+		}
+		if occurrence := spaniel.New(start, start.Add(s.duration)); spaniel.Overlaps(occurrence, window) {
+			expanded = append(expanded, occurrenceHandlerFunc(occurrence))
+		}
+	}
+	return expanded
+}
+
+// Expand returns a list of Spans covering each occurrence of the schedule which overlaps the window, in order of their
+// start. See ExpandWithHandler.
+func (s *Schedule) Expand(window spaniel.Span) spaniel.Spans {
+	return s.ExpandWithHandler(window, func(occurrence spaniel.Span) spaniel.Span {
+		return occurrence
+	})
+}
+
+// Returns the time shown on the clock in t's location at t, as a time in UTC, so that the calendar can be worked with
+// without the clocks changing.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// Returns the time at which the clock in loc shows the wall clock time given in UTC. When the clocks go back and show
+// the time twice, the first is used. When the clocks go forward and skip the time, it is interpreted using the offset
+// from UTC before the gap, as RFC 5545 requires, so that it falls the same distance after the gap.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(),
+		wall.Nanosecond(), loc)
+
+	// The clocks don't change more than once in half a day, so the offset then is the one before any change.
+	_, offset := t.Add(-12 * time.Hour).Zone()
+	before := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+	if !wallClock(t).Equal(wall) || (wallClock(before).Equal(wall) && before.Before(t)) {
+		return before
+	}
+	return t
+}
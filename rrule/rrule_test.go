@@ -0,0 +1,396 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	timespan "github.com/senseyeio/spaniel"
+	"github.com/senseyeio/spaniel/rrule"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// Returns the time shown on the clock in loc, given as in an iCalendar property, such as 19970902T090000.
+func at(t *testing.T, s string, loc *time.Location) time.Time {
+	t.Helper()
+	v, err := time.ParseInLocation("20060102T150405", s, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// Returns the starts of the spans as they would appear in an iCalendar property in loc.
+func starts(spans timespan.Spans, loc *time.Location) []string {
+	formatted := []string{}
+	for _, span := range spans {
+		formatted = append(formatted, span.Start().In(loc).Format("20060102T150405"))
+	}
+	return formatted
+}
+
+func expectStarts(t *testing.T, spans timespan.Spans, loc *time.Location, expected []string) {
+	t.Helper()
+	actual := starts(spans, loc)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d occurrences %v, got %d %v", len(expected), expected, len(actual), actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	// The examples of RFC 5545 section 3.8.5.3, which are all in New York.
+	newYork := loadLocation(t, "America/New_York")
+
+	for _, tt := range []struct {
+		name       string
+		dtstart    string
+		properties []string
+		until      string
+		expected   []string
+	}{
+		{
+			name:       "Should return only the DTSTART without any rules",
+			dtstart:    "19970902T090000",
+			properties: nil,
+			expected:   []string{"19970902T090000"},
+		},
+		{
+			name:       "Should recur daily for a count",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=DAILY;COUNT=10"},
+			expected: []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000",
+				"19970906T090000", "19970907T090000", "19970908T090000", "19970909T090000", "19970910T090000",
+				"19970911T090000"},
+		},
+		{
+			name:       "Should recur until a time in UTC",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=DAILY;INTERVAL=10;UNTIL=19971002T130000Z"},
+			expected:   []string{"19970902T090000", "19970912T090000", "19970922T090000", "19971002T090000"},
+		},
+		{
+			name:       "Should accept a rule without the RRULE prefix",
+			dtstart:    "19970902T090000",
+			properties: []string{"FREQ=DAILY;INTERVAL=2;COUNT=3"},
+			expected:   []string{"19970902T090000", "19970904T090000", "19970906T090000"},
+		},
+		{
+			name:       "Should recur weekly on several days",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH"},
+			expected: []string{"19970902T090000", "19970904T090000", "19970909T090000", "19970911T090000",
+				"19970916T090000", "19970918T090000", "19970923T090000", "19970925T090000", "19970930T090000",
+				"19971002T090000"},
+		},
+		{
+			name:       "Should recur every other week with weeks starting on the given day",
+			dtstart:    "19970805T090000",
+			properties: []string{"RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU"},
+			expected:   []string{"19970805T090000", "19970817T090000", "19970819T090000", "19970831T090000"},
+		},
+		{
+			name:       "Should recur monthly on a numbered day of the week",
+			dtstart:    "19970905T090000",
+			properties: []string{"RRULE:FREQ=MONTHLY;COUNT=6;BYDAY=1FR"},
+			expected: []string{"19970905T090000", "19971003T090000", "19971107T090000", "19971205T090000",
+				"19980102T090000", "19980206T090000"},
+		},
+		{
+			name:       "Should recur monthly on a day of the week counted from the end",
+			dtstart:    "19970922T090000",
+			properties: []string{"RRULE:FREQ=MONTHLY;COUNT=6;BYDAY=-2MO"},
+			expected: []string{"19970922T090000", "19971020T090000", "19971117T090000", "19971222T090000",
+				"19980119T090000", "19980216T090000"},
+		},
+		{
+			name:       "Should recur monthly on a day counted from the end of the month",
+			dtstart:    "19970928T090000",
+			properties: []string{"RRULE:FREQ=MONTHLY;BYMONTHDAY=-3"},
+			until:      "19980301T000000",
+			expected: []string{"19970928T090000", "19971029T090000", "19971128T090000", "19971229T090000",
+				"19980129T090000", "19980226T090000"},
+		},
+		{
+			name:       "Should skip months without the day of the DTSTART",
+			dtstart:    "19970131T090000",
+			properties: []string{"RRULE:FREQ=MONTHLY;COUNT=4"},
+			expected:   []string{"19970131T090000", "19970331T090000", "19970531T090000", "19970731T090000"},
+		},
+		{
+			name:       "Should select positions within each period",
+			dtstart:    "19970929T090000",
+			properties: []string{"RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2;COUNT=4"},
+			expected:   []string{"19970929T090000", "19971030T090000", "19971127T090000", "19971230T090000"},
+		},
+		{
+			name:       "Should recur yearly in several months",
+			dtstart:    "19970610T090000",
+			properties: []string{"RRULE:FREQ=YEARLY;COUNT=6;BYMONTH=6,7"},
+			until:      "20000101T000000",
+			expected: []string{"19970610T090000", "19970710T090000", "19980610T090000", "19980710T090000",
+				"19990610T090000", "19990710T090000"},
+		},
+		{
+			name:       "Should recur yearly on a day of the year",
+			dtstart:    "19970101T090000",
+			properties: []string{"RRULE:FREQ=YEARLY;INTERVAL=3;COUNT=4;BYYEARDAY=1,100,200"},
+			until:      "20010101T000000",
+			expected:   []string{"19970101T090000", "19970410T090000", "19970719T090000", "20000101T090000"},
+		},
+		{
+			name:       "Should recur yearly on a numbered day of the week within the year",
+			dtstart:    "19970519T090000",
+			properties: []string{"RRULE:FREQ=YEARLY;BYDAY=20MO"},
+			until:      "20000101T000000",
+			expected:   []string{"19970519T090000", "19980518T090000", "19990517T090000"},
+		},
+		{
+			name:       "Should recur yearly in a week of the year",
+			dtstart:    "19970512T090000",
+			properties: []string{"RRULE:FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO"},
+			until:      "20000101T000000",
+			expected:   []string{"19970512T090000", "19980511T090000", "19990517T090000"},
+		},
+		{
+			name:       "Should include days of a week belonging to the year before",
+			dtstart:    "19981228T090000",
+			properties: []string{"RRULE:FREQ=YEARLY;BYWEEKNO=53;BYDAY=FR"},
+			until:      "20050101T000000",
+			expected:   []string{"19981228T090000", "19990101T090000", "20041231T090000"},
+		},
+		{
+			name:       "Should recur on Friday the 13th, leaving out an excluded DTSTART",
+			dtstart:    "19970902T090000",
+			properties: []string{"EXDATE;TZID=America/New_York:19970902T090000", "RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13"},
+			until:      "20000101T000000",
+			expected:   []string{"19980213T090000", "19980313T090000", "19981113T090000", "19990813T090000"},
+		},
+		{
+			name:       "Should recur hourly",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000"},
+			expected:   []string{"19970902T090000", "19970902T120000", "19970902T150000"},
+		},
+		{
+			name:       "Should recur every few minutes within the given hours",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10;COUNT=8"},
+			expected: []string{"19970902T090000", "19970902T092000", "19970902T094000", "19970902T100000",
+				"19970902T102000", "19970902T104000", "19970903T090000", "19970903T092000"},
+		},
+		{
+			name:       "Should add dates and leave out excluded ones",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=DAILY;COUNT=3\nRDATE;TZID=America/New_York:19970902T140000,19970910T090000", "EXDATE:19970903T130000Z"},
+			expected:   []string{"19970902T090000", "19970902T140000", "19970904T090000", "19970910T090000"},
+		},
+		{
+			name:       "Should take dates without times to be at the time of the DTSTART",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=DAILY;UNTIL=19970905", "EXDATE;VALUE=DATE:19970904"},
+			expected:   []string{"19970902T090000", "19970903T090000", "19970905T090000"},
+		},
+		{
+			name:       "Should only return an occurrence of several rules once",
+			dtstart:    "19970902T090000",
+			properties: []string{"RRULE:FREQ=DAILY;COUNT=3", "RRULE:FREQ=DAILY;INTERVAL=2;COUNT=3"},
+			expected:   []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970906T090000"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			until := "19990101T000000"
+			if tt.until != "" {
+				until = tt.until
+			}
+			schedule, err := rrule.New(at(t, tt.dtstart, newYork), time.Hour, tt.properties...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			window := timespan.New(at(t, "19970101T000000", newYork), at(t, until, newYork))
+			expectStarts(t, schedule.Expand(window), newYork, tt.expected)
+		})
+	}
+}
+
+func TestExpandWindow(t *testing.T) {
+	dtstart := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	schedule, err := rrule.New(dtstart, time.Hour, "RRULE:FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should include an occurrence overlapping the start of the window", func(t *testing.T) {
+		window := timespan.New(dtstart.Add(30*time.Minute), dtstart.Add(24*time.Hour))
+		expected := timespan.Spans{timespan.New(dtstart, dtstart.Add(time.Hour))}
+		actual := schedule.Expand(window)
+		if len(actual) != 1 || !timespan.Equal(actual[0], expected[0]) {
+			t.Fatalf("expected %v, got %v", expected, actual)
+		}
+	})
+
+	t.Run("Should include an occurrence at a Closed end of the window", func(t *testing.T) {
+		window := timespan.NewWithTypes(dtstart.Add(30*time.Minute), dtstart.Add(24*time.Hour), timespan.Closed, timespan.Closed)
+		expectStarts(t, schedule.Expand(window), time.UTC, []string{"20210101T090000", "20210102T090000"})
+	})
+
+	t.Run("Should find occurrences long after the DTSTART", func(t *testing.T) {
+		window := timespan.New(time.Date(2121, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2121, 1, 3, 0, 0, 0, 0, time.UTC))
+		expectStarts(t, schedule.Expand(window), time.UTC, []string{"21210101T090000", "21210102T090000"})
+	})
+
+	t.Run("Should keep to the interval long after the DTSTART", func(t *testing.T) {
+		schedule, err := rrule.New(dtstart, 0, "RRULE:FREQ=MINUTELY;INTERVAL=7")
+		if err != nil {
+			t.Fatal(err)
+		}
+		from := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+		actual := schedule.Expand(timespan.New(from, from.Add(time.Hour)))
+		if len(actual) == 0 {
+			t.Fatal("expected occurrences")
+		}
+		for _, occurrence := range actual {
+			if !timespan.IsInstant(occurrence) || occurrence.Start().Sub(dtstart)%(7*time.Minute) != 0 {
+				t.Fatalf("expected instants every 7 minutes from %v, got %v", dtstart, occurrence)
+			}
+		}
+	})
+
+	t.Run("Should pass each occurrence to the handler", func(t *testing.T) {
+		window := timespan.New(dtstart, dtstart.Add(48*time.Hour))
+		handled := 0
+		actual := schedule.ExpandWithHandler(window, func(occurrence timespan.Span) timespan.Span {
+			handled++
+			return timespan.NewInstant(occurrence.Start())
+		})
+		if handled != 2 || len(actual) != 2 || !timespan.IsInstant(actual[1]) {
+			t.Fatalf("expected 2 handled instants, got %d %v", handled, actual)
+		}
+	})
+}
+
+func TestExpandDaylightSaving(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+
+	for _, tt := range []struct {
+		name     string
+		dtstart  string
+		rule     string
+		from, to string
+		expected []time.Time
+	}{
+		{
+			name:    "Should keep to the local time when the clocks go forward",
+			dtstart: "20210326T090000",
+			rule:    "FREQ=DAILY;COUNT=3",
+			expected: []time.Time{
+				time.Date(2021, 3, 26, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 27, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 28, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "Should keep to the local time when the clocks go back",
+			dtstart: "20211030T090000",
+			rule:    "FREQ=WEEKLY;COUNT=2",
+			expected: []time.Time{
+				time.Date(2021, 10, 30, 8, 0, 0, 0, time.UTC),
+				time.Date(2021, 11, 6, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "Should move a time skipped by the clocks going forward to after the gap",
+			dtstart: "20210327T013000",
+			rule:    "FREQ=DAILY;COUNT=3",
+			expected: []time.Time{
+				time.Date(2021, 3, 27, 1, 30, 0, 0, time.UTC),
+				time.Date(2021, 3, 28, 1, 30, 0, 0, time.UTC),
+				time.Date(2021, 3, 29, 0, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "Should use the first of a time repeated by the clocks going back",
+			dtstart: "20211030T013000",
+			rule:    "FREQ=DAILY;COUNT=3",
+			expected: []time.Time{
+				time.Date(2021, 10, 30, 0, 30, 0, 0, time.UTC),
+				time.Date(2021, 10, 31, 0, 30, 0, 0, time.UTC),
+				time.Date(2021, 11, 1, 1, 30, 0, 0, time.UTC),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := rrule.New(at(t, tt.dtstart, london), 2*time.Hour, tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			window := timespan.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+			actual := schedule.Expand(window)
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, actual)
+			}
+			for i, start := range tt.expected {
+				if !actual[i].Start().Equal(start) || actual[i].End().Sub(actual[i].Start()) != 2*time.Hour {
+					t.Fatalf("expected occurrences of 2 hours starting at %v, got %v", tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	dtstart := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name     string
+		property string
+	}{
+		{name: "Should reject a rule without a frequency", property: "RRULE:COUNT=3"},
+		{name: "Should reject an unknown frequency", property: "RRULE:FREQ=FORTNIGHTLY"},
+		{name: "Should reject a rule with both COUNT and UNTIL", property: "RRULE:FREQ=DAILY;COUNT=3;UNTIL=20210105T000000Z"},
+		{name: "Should reject a repeated rule part", property: "RRULE:FREQ=DAILY;FREQ=WEEKLY"},
+		{name: "Should reject an unknown rule part", property: "RRULE:FREQ=DAILY;BYEASTER=1"},
+		{name: "Should reject a value out of range", property: "RRULE:FREQ=DAILY;BYHOUR=24"},
+		{name: "Should reject an invalid day", property: "RRULE:FREQ=WEEKLY;BYDAY=XX"},
+		{name: "Should reject a numbered day in a WEEKLY rule", property: "RRULE:FREQ=WEEKLY;BYDAY=1MO"},
+		{name: "Should reject BYWEEKNO outside a YEARLY rule", property: "RRULE:FREQ=MONTHLY;BYWEEKNO=1"},
+		{name: "Should reject BYSETPOS on its own", property: "RRULE:FREQ=MONTHLY;BYSETPOS=1"},
+		{name: "Should reject an unknown time zone", property: "EXDATE;TZID=Nowhere/Special:20210102T090000"},
+		{name: "Should reject an invalid time", property: "RDATE:2021-01-02 09:00"},
+		{name: "Should reject periods", property: "RDATE;VALUE=PERIOD:20210102T090000Z/20210102T100000Z"},
+		{name: "Should reject unsupported properties", property: "EXRULE:FREQ=DAILY"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := rrule.New(dtstart, time.Hour, tt.property); err == nil {
+				t.Fatalf("expected an error for %q", tt.property)
+			}
+		})
+	}
+
+	t.Run("Should reject a negative duration", func(t *testing.T) {
+		if _, err := rrule.New(dtstart, -time.Hour); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("Should unfold folded lines", func(t *testing.T) {
+		schedule, err := rrule.New(dtstart, time.Hour, "RRULE:FREQ=DAILY;\r\n COUNT=2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		window := timespan.New(dtstart, dtstart.Add(7*24*time.Hour))
+		expectStarts(t, schedule.Expand(window), time.UTC, []string{"20210101T090000", "20210102T090000"})
+	})
+}
@@ -0,0 +1,504 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The FREQ of a rule, from the longest period to the shortest.
+type frequency int
+
+const (
+	yearly frequency = iota
+	monthly
+	weekly
+	daily
+	hourly
+	minutely
+	secondly
+)
+
+var frequencies = map[string]frequency{
+	"YEARLY":   yearly,
+	"MONTHLY":  monthly,
+	"WEEKLY":   weekly,
+	"DAILY":    daily,
+	"HOURLY":   hourly,
+	"MINUTELY": minutely,
+	"SECONDLY": secondly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// A day of the week in a BYDAY rule part, such as MO for every Monday, 2MO for the second Monday, or -1MO for the last.
+type weekday struct {
+	day time.Weekday
+	n   int
+}
+
+// A parsed RRULE, with the rule parts left out of it filled in from the DTSTART, so that it can be expanded by
+// filtering the days and times of each period.
+type rule struct {
+	freq       frequency
+	interval   int
+	count      int
+	until      time.Time
+	bySecond   []int
+	byMinute   []int
+	byHour     []int
+	byDay      []weekday
+	byMonthDay []int
+	byYearDay  []int
+	byWeekNo   []int
+	byMonth    []int
+	bySetPos   []int
+	wkst       time.Weekday
+}
+
+// Parses the value of an RRULE property, such as FREQ=WEEKLY;BYDAY=MO,WE, for a recurrence starting at dtstart.
+func parseRule(value string, dtstart time.Time) (*rule, error) {
+	r := &rule{interval: 1, wkst: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		name = strings.ToUpper(name)
+		if seen[name] {
+			return nil, fmt.Errorf("repeated rule part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			freq, ok := frequencies[strings.ToUpper(v)]
+			if !ok {
+				err = errors.New("unknown frequency")
+			}
+			r.freq = freq
+		case "INTERVAL":
+			r.interval, err = parseInt(v, 1, math.MaxInt32)
+		case "COUNT":
+			r.count, err = parseInt(v, 1, math.MaxInt32)
+		case "UNTIL":
+			r.until, err = parseUntil(v, dtstart.Location())
+		case "BYSECOND":
+			r.bySecond, err = parseInts(v, 0, 60, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseInts(v, 0, 59, false)
+		case "BYHOUR":
+			r.byHour, err = parseInts(v, 0, 23, false)
+		case "BYDAY":
+			r.byDay, err = parseWeekdays(v)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseInts(v, 1, 31, true)
+		case "BYYEARDAY":
+			r.byYearDay, err = parseInts(v, 1, 366, true)
+		case "BYWEEKNO":
+			r.byWeekNo, err = parseInts(v, 1, 53, true)
+		case "BYMONTH":
+			r.byMonth, err = parseInts(v, 1, 12, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseInts(v, 1, 366, true)
+		case "WKST":
+			day, ok := weekdays[strings.ToUpper(v)]
+			if !ok {
+				err = errors.New("unknown day")
+			}
+			r.wkst = day
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", name, v, err)
+		}
+	}
+
+	if err := r.validate(seen); err != nil {
+		return nil, err
+	}
+	r.fillDefaults(wallClock(dtstart))
+	return r, nil
+}
+
+// Returns an error if the rule breaks any of the restrictions RFC 5545 places on combining rule parts.
+func (r *rule) validate(seen map[string]bool) error {
+	switch {
+	case !seen["FREQ"]:
+		return errors.New("rule has no FREQ")
+	case seen["COUNT"] && seen["UNTIL"]:
+		return errors.New("rule has both COUNT and UNTIL")
+	case r.byWeekNo != nil && r.freq != yearly:
+		return errors.New("BYWEEKNO is only allowed in YEARLY rules")
+	case r.byYearDay != nil && (r.freq == monthly || r.freq == weekly || r.freq == daily):
+		return errors.New("BYYEARDAY is not allowed in MONTHLY, WEEKLY or DAILY rules")
+	case r.byMonthDay != nil && r.freq == weekly:
+		return errors.New("BYMONTHDAY is not allowed in WEEKLY rules")
+	case r.bySetPos != nil && !hasOtherBy(seen):
+		return errors.New("BYSETPOS is only allowed with another BYxxx rule part")
+	}
+
+	ordinals := r.freq == monthly || (r.freq == yearly && r.byWeekNo == nil)
+	for _, w := range r.byDay {
+		if w.n != 0 && !ordinals {
+			return errors.New("BYDAY can only be numbered in MONTHLY rules, or YEARLY rules without BYWEEKNO")
+		}
+	}
+	return nil
+}
+
+// Returns true if a BYxxx rule part other than BYSETPOS has been seen.
+func hasOtherBy(seen map[string]bool) bool {
+	for name := range seen {
+		if strings.HasPrefix(name, "BY") && name != "BYSETPOS" {
+			return true
+		}
+	}
+	return false
+}
+
+// Fills in the rule parts which are taken from the DTSTART when left out, given as a wall clock time. The days are
+// only filled in when none of the rule parts choosing days are given, so for example a MONTHLY rule recurs on the day
+// of the month of the DTSTART. The times are filled in for periods longer than them, so for example a DAILY rule
+// recurs at the time of day of the DTSTART.
+func (r *rule) fillDefaults(start time.Time) {
+	if r.byWeekNo == nil && r.byYearDay == nil && r.byMonthDay == nil && r.byDay == nil {
+		switch r.freq {
+		case yearly:
+			if r.byMonth == nil {
+				r.byMonth = []int{int(start.Month())}
+			}
+			r.byMonthDay = []int{start.Day()}
+		case monthly:
+			r.byMonthDay = []int{start.Day()}
+		case weekly:
+			r.byDay = []weekday{{day: start.Weekday()}}
+		}
+	}
+	if r.freq < hourly && r.byHour == nil {
+		r.byHour = []int{start.Hour()}
+	}
+	if r.freq < minutely && r.byMinute == nil {
+		r.byMinute = []int{start.Minute()}
+	}
+	if r.freq < secondly && r.bySecond == nil {
+		r.bySecond = []int{start.Second()}
+	}
+}
+
+// Parses an integer from lo to hi.
+func parseInt(s string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < lo || n > hi {
+		return 0, fmt.Errorf("%d is not between %d and %d", n, lo, hi)
+	}
+	return n, nil
+}
+
+// Parses a comma separated list of integers from lo to hi, or from -hi to -lo as well if signed is true, returning
+// them sorted without duplicates.
+func parseInts(s string, lo, hi int, signed bool) ([]int, error) {
+	values := []int{}
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		if (n < lo || n > hi) && (!signed || -n < lo || -n > hi) {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		values = append(values, n)
+	}
+	slices.Sort(values)
+	return slices.Compact(values), nil
+}
+
+// Parses a comma separated list of days of the week, each optionally numbered, such as MO,-1FR.
+func parseWeekdays(s string) ([]weekday, error) {
+	days := []weekday{}
+	for _, v := range strings.Split(s, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("invalid day %q", v)
+		}
+		day, ok := weekdays[strings.ToUpper(v[len(v)-2:])]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", v)
+		}
+		w := weekday{day: day}
+		if ordinal := v[:len(v)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid day %q", v)
+			}
+			w.n = n
+		}
+		days = append(days, w)
+	}
+	return days, nil
+}
+
+// Parses the UNTIL of a rule, which is a time in UTC, a local time in loc, or a date, in which case the rule runs
+// until the end of that day in loc.
+func parseUntil(s string, loc *time.Location) (time.Time, error) {
+	if date, err := time.Parse(dateLayout, s); err == nil {
+		return inLocation(date.AddDate(0, 0, 1), loc).Add(-time.Nanosecond), nil
+	}
+	return parseDateTime(s, loc)
+}
+
+// Returns the start of the current period, plus the given number of intervals, as a wall clock time, along with the
+// days that the period covers. The days of HOURLY, MINUTELY and SECONDLY periods are the day they fall on.
+func (r *rule) period(start time.Time, intervals int) (time.Time, []time.Time) {
+	n := intervals * r.interval
+	year, month, day := start.Date()
+	hour, minute, second := start.Clock()
+
+	var from time.Time
+	days := 1
+	switch r.freq {
+	case yearly:
+		from = date(year+n, time.January, 1)
+		days = daysIn(from.Year())
+	case monthly:
+		from = date(year, month+time.Month(n), 1)
+		days = daysInMonth(from)
+	case weekly:
+		from = date(year, month, day-(int(start.Weekday())-int(r.wkst)+7)%7+7*n)
+		days = 7
+	case daily:
+		from = date(year, month, day+n)
+	case hourly:
+		from = time.Date(year, month, day, hour+n, 0, 0, 0, time.UTC)
+	case minutely:
+		from = time.Date(year, month, day, hour, minute+n, 0, 0, time.UTC)
+	case secondly:
+		from = time.Date(year, month, day, hour, minute, second+n, 0, time.UTC)
+	}
+
+	first := date(from.Year(), from.Month(), from.Day())
+	covered := make([]time.Time, days)
+	for i := range covered {
+		covered[i] = first.AddDate(0, 0, i)
+	}
+	return from, covered
+}
+
+// Returns the wall clock times of the occurrences within the period starting at from, which covers the given days.
+func (r *rule) expand(from time.Time, days []time.Time) []time.Time {
+	hours, minutes, seconds := r.byHour, r.byMinute, r.bySecond
+	if r.freq >= hourly {
+		hours = fixed(from.Hour(), r.byHour)
+	}
+	if r.freq >= minutely {
+		minutes = fixed(from.Minute(), r.byMinute)
+	}
+	if r.freq == secondly {
+		seconds = fixed(from.Second(), r.bySecond)
+	}
+
+	occurrences := []time.Time{}
+	for _, day := range days {
+		if !r.matchDay(day) {
+			continue
+		}
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				for _, second := range seconds {
+					occurrences = append(occurrences,
+						time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, time.UTC))
+				}
+			}
+		}
+	}
+
+	if r.bySetPos == nil {
+		return occurrences
+	}
+	selected := []time.Time{}
+	for i, occurrence := range occurrences {
+		if matchPosition(r.bySetPos, i+1, len(occurrences)) {
+			selected = append(selected, occurrence)
+		}
+	}
+	return selected
+}
+
+// Returns the given value of a period as the only one to use, if the values allowed by a rule part include it.
+func fixed(value int, allowed []int) []int {
+	if allowed != nil && !slices.Contains(allowed, value) {
+		return nil
+	}
+	return []int{value}
+}
+
+// Returns true if the day is allowed by each of the rule parts which choose days.
+func (r *rule) matchDay(day time.Time) bool {
+	switch {
+	case r.byMonth != nil && !slices.Contains(r.byMonth, int(day.Month())):
+		return false
+	case r.byWeekNo != nil && !r.matchWeekNo(day):
+		return false
+	case r.byYearDay != nil && !matchPosition(r.byYearDay, day.YearDay(), daysIn(day.Year())):
+		return false
+	case r.byMonthDay != nil && !matchPosition(r.byMonthDay, day.Day(), daysInMonth(day)):
+		return false
+	case r.byDay != nil && !r.matchWeekday(day):
+		return false
+	}
+	return true
+}
+
+// Returns true if the day of the week is one of those in BYDAY. Numbered days count that day of the week within the
+// month, or within the year in a YEARLY rule without BYMONTH.
+func (r *rule) matchWeekday(day time.Time) bool {
+	n, last := day.Day(), daysInMonth(day)
+	if r.freq == yearly && r.byMonth == nil {
+		n, last = day.YearDay(), daysIn(day.Year())
+	}
+	for _, w := range r.byDay {
+		if w.day != day.Weekday() {
+			continue
+		}
+		if w.n == 0 || w.n == (n-1)/7+1 || w.n == -((last-n)/7+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the week of the year containing the day is one of those in BYWEEKNO. Days in the first or last
+// week of the year may belong to a week of the year before or after.
+func (r *rule) matchWeekNo(day time.Time) bool {
+	year, n := day.Year(), day.YearDay()-1
+	first := firstWeek(year, r.wkst)
+	if n < first {
+		year--
+		n += daysIn(year)
+		first = firstWeek(year, r.wkst)
+	} else if next := daysIn(year) + firstWeek(year+1, r.wkst); n >= next {
+		n -= daysIn(year)
+		year++
+		first = firstWeek(year, r.wkst)
+	}
+	weeks := (daysIn(year) + firstWeek(year+1, r.wkst) - first) / 7
+	return matchPosition(r.byWeekNo, (n-first)/7+1, weeks)
+}
+
+// Returns the day of the year, counting from zero, on which the first week of the year starts, where weeks start on
+// wkst. This is the first week with at least four days in the year, and so the week containing the 4th of January,
+// so it may start in the year before, in which case the day is negative.
+func firstWeek(year int, wkst time.Weekday) int {
+	jan4 := date(year, time.January, 4)
+	return 3 - (int(jan4.Weekday())-int(wkst)+7)%7
+}
+
+// Returns true if positions includes n, counting from 1, or the same position counting back from last, where -1 is
+// last.
+func matchPosition(positions []int, n, last int) bool {
+	return slices.Contains(positions, n) || slices.Contains(positions, n-last-1)
+}
+
+// Returns the start of the occurrences of the rule which start from 'from' to 'to', in order. The occurrences are
+// counted from the DTSTART, and found on the wall clock in its location.
+func (r *rule) occurrences(dtstart, from, to time.Time) []time.Time {
+	loc := dtstart.Location()
+	start := wallClock(dtstart)
+
+	// Periods before the first can be skipped, as long as the occurrences in them don't need counting.
+	intervals := 0
+	if r.count == 0 {
+		intervals = r.skip(start, wallClock(from.In(loc)))
+	}
+
+	occurrences := []time.Time{}
+	counted := 0
+	for ; ; intervals++ {
+		period, days := r.period(start, intervals)
+		if begin := inLocation(period, loc); begin.After(to) || (!r.until.IsZero() && begin.After(r.until)) {
+			return occurrences
+		}
+
+		for _, t := range localTimes(r.expand(period, days), loc) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !r.until.IsZero() && t.After(r.until) {
+				return occurrences
+			}
+			if !t.Before(from) && !t.After(to) {
+				occurrences = append(occurrences, t)
+			}
+			counted++
+			if counted == r.count {
+				return occurrences
+			}
+		}
+	}
+}
+
+// Returns the number of intervals which can be skipped from the period of start, given as a wall clock time, without
+// skipping any which overlap the wall clock time from.
+func (r *rule) skip(start, from time.Time) int {
+	var periods int
+	switch r.freq {
+	case yearly:
+		periods = from.Year() - start.Year()
+	case monthly:
+		periods = (from.Year()-start.Year())*12 + int(from.Month()) - int(start.Month())
+	case weekly:
+		periods = int(from.Sub(start) / (7 * 24 * time.Hour))
+	case daily:
+		periods = int(from.Sub(start) / (24 * time.Hour))
+	case hourly:
+		periods = int(from.Sub(start) / time.Hour)
+	case minutely:
+		periods = int(from.Sub(start) / time.Minute)
+	case secondly:
+		periods = int(from.Sub(start) / time.Second)
+	}
+	return max(periods/r.interval-1, 0)
+}
+
+// Returns the wall clock times in loc, in order and without duplicates, as clocks going forward may move a time past
+// those after it.
+func localTimes(walls []time.Time, loc *time.Location) []time.Time {
+	times := make([]time.Time, len(walls))
+	for i, wall := range walls {
+		times[i] = inLocation(wall, loc)
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return slices.CompactFunc(times, time.Time.Equal)
+}
+
+// Returns midnight at the start of a day, as a wall clock time.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the number of days in the year.
+func daysIn(year int) int {
+	return date(year, time.December, 31).YearDay()
+}
+
+// Returns the number of days in the month of the day.
+func daysInMonth(day time.Time) int {
+	return date(day.Year(), day.Month()+1, 0).Day()
+}
@@ -0,0 +1,78 @@
+package spaniel
+
+import (
+	"time"
+)
+
+// SpanSet holds a set of time as a sorted list of spans which don't overlap and aren't contiguous with one another,
+// as would be returned by Union. Spans can be added and removed one at a time, each taking logarithmic time in the
+// number of spans held, rather than the whole list being merged again. The spans held are all TimeSpans, with the
+// endpoint types given by the same rules as Union and Difference. The zero value is an empty set, ready to use.
+type SpanSet struct {
+	index Index
+}
+
+// NewSpanSet creates a SpanSet holding the time covered by the given spans.
+func NewSpanSet(spans Spans) *SpanSet {
+	set := &SpanSet{}
+	for _, span := range spans {
+		set.Add(span)
+	}
+	return set
+}
+
+// Len returns the number of spans held in the set.
+func (set *SpanSet) Len() int {
+	return set.index.Len()
+}
+
+// Spans returns the sorted list of spans held in the set.
+func (set *SpanSet) Spans() Spans {
+	return set.index.Spans()
+}
+
+// Add adds the time covered by the span to the set, merging it with any of the spans held which it overlaps or is
+// contiguous with. Empty spans, as reported by IsEmpty, are ignored.
+func (set *SpanSet) Add(span Span) {
+	if IsEmpty(span) {
+		return
+	}
+
+	var merged Span = NewWithTypes(span.Start(), span.End(), span.StartType(), span.EndType())
+	for _, held := range set.index.touching(span) {
+		set.index.Delete(held)
+		merged = merge(merged, held)
+	}
+	set.index.Insert(merged)
+}
+
+// Remove removes the time covered by the span from the set, cutting any of the spans held which it overlaps. Empty
+// spans, as reported by IsEmpty, are ignored.
+func (set *SpanSet) Remove(span Span) {
+	if IsEmpty(span) {
+		return
+	}
+
+	for _, held := range set.index.Overlapping(span) {
+		set.index.Delete(held)
+		for _, fragment := range (Spans{held}).Difference(Spans{span}) {
+			set.index.Insert(fragment)
+		}
+	}
+}
+
+// Contains returns true if the time t is covered by the set.
+func (set *SpanSet) Contains(t time.Time) bool {
+	return len(set.index.At(t)) > 0
+}
+
+// ContainsSpan returns true if all of the time covered by the span is also covered by the set.
+func (set *SpanSet) ContainsSpan(span Span) bool {
+	if IsEmpty(span) {
+		return true
+	}
+
+	// The spans held are separated by gaps, so the span can only be covered if it lies within a single one of them.
+	held := set.index.Overlapping(span)
+	return len(held) == 1 && ContainsSpan(held[0], span)
+}
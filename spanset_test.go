@@ -0,0 +1,86 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestSpanSet(t *testing.T) {
+
+	t.Run("Should be empty by default", func(t *testing.T) {
+		var set timespan.SpanSet
+		expectEqual(t, set.Len(), 0)
+		expectEqual(t, set.Spans(), timespan.Spans{})
+		expectEqual(t, set.Contains(now), false)
+	})
+
+	t.Run("Should merge overlapping and contiguous spans", func(t *testing.T) {
+		set := timespan.NewSpanSet(timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)),
+		})
+		set.Add(timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour)))
+		expectEqual(t, set.Spans(), timespan.Spans{timespan.New(now, now.Add(3*time.Hour))})
+	})
+
+	t.Run("Should keep separate spans apart", func(t *testing.T) {
+		a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Open)
+		b := timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Open, timespan.Open)
+		set := timespan.NewSpanSet(timespan.Spans{b, a})
+		expectEqual(t, set.Spans(), timespan.Spans{a, b})
+		expectEqual(t, set.Contains(now.Add(time.Hour)), false)
+
+		set.Add(timespan.NewInstant(now.Add(time.Hour)))
+		expectEqual(t, set.Spans(), timespan.Spans{timespan.NewWithTypes(now, now.Add(2*time.Hour), timespan.Closed, timespan.Open)})
+	})
+
+	t.Run("Should ignore empty spans", func(t *testing.T) {
+		set := timespan.NewSpanSet(timespan.Spans{NewEvent(now, now)})
+		expectEqual(t, set.Len(), 0)
+	})
+
+	t.Run("Should remove time from the spans held", func(t *testing.T) {
+		set := timespan.NewSpanSet(timespan.Spans{
+			timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed),
+			timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)),
+		})
+		set.Remove(timespan.New(now.Add(30*time.Minute), now.Add(150*time.Minute)))
+		expectEqual(t, set.Spans(), timespan.Spans{
+			timespan.New(now, now.Add(30*time.Minute)),
+			timespan.New(now.Add(150*time.Minute), now.Add(3*time.Hour)),
+		})
+
+		set.Remove(timespan.New(now.Add(-time.Hour), now.Add(4*time.Hour)))
+		expectEqual(t, set.Spans(), timespan.Spans{})
+	})
+
+	t.Run("Should report whether it contains times and spans", func(t *testing.T) {
+		set := timespan.NewSpanSet(timespan.Spans{
+			timespan.New(now, now.Add(time.Hour)),
+			timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour)),
+		})
+		expectEqual(t, set.Contains(now), true)
+		expectEqual(t, set.Contains(now.Add(time.Hour)), false)
+		expectEqual(t, set.ContainsSpan(timespan.New(now.Add(10*time.Minute), now.Add(20*time.Minute))), true)
+		expectEqual(t, set.ContainsSpan(timespan.New(now, now.Add(time.Hour))), true)
+		expectEqual(t, set.ContainsSpan(timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Closed, timespan.Closed)), false)
+		expectEqual(t, set.ContainsSpan(timespan.New(now, now.Add(3*time.Hour))), false)
+	})
+
+	t.Run("Should match Union and Difference", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		added := randomSpans(r, 300)
+		removed := randomSpans(r, 50)
+
+		set := timespan.NewSpanSet(added)
+		expectEqual(t, set.Spans(), added.Union())
+
+		for _, span := range removed {
+			set.Remove(span)
+		}
+		expectEqual(t, set.Spans(), added.Union().Difference(removed))
+	})
+}
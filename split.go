@@ -0,0 +1,58 @@
+package spaniel
+
+import (
+	"fmt"
+	"time"
+)
+
+// SplitHandlerFunc is used by SplitWithHandler to allow for custom functionality when a span is cut at a boundary.
+// It is passed the span which has been cut, and the span representing each of the fragments it has been cut into.
+type SplitHandlerFunc func(original, fragment Span) Span
+
+// SplitWithHandler returns a list of Spans in which each of the contained spans is cut at every boundary which falls
+// strictly within it, as given by the boundary function in the location loc.
+// For example, given a list [A] where A runs from 22:00 on one day to 02:00 on the next, and DayBoundary, a list [B,C]
+// would be returned, with B covering A up to midnight, and C covering A from midnight. Each cut results in an Open end
+// and a Closed start at the boundary, so the fragments are [) apart from the start of the first and the end of the
+// last, which keep the types of the span they were cut from. The fragments of each span are returned in order, in
+// place of the span. The provided handler is passed the span being cut, and the span representing each of the
+// fragments. Spans which don't cross a boundary are returned unchanged. It panics if the boundary function returns a
+// time which is not after the time it was given, as the span could otherwise never be cut up.
+func (s Spans) SplitWithHandler(boundary Boundary, loc *time.Location, splitHandlerFunc SplitHandlerFunc) Spans {
+	split := Spans{}
+	for _, span := range s {
+		next := nextBoundary(boundary, span.Start(), loc)
+		if !next.Before(span.End()) {
+			split = append(split, span)
+			continue
+		}
+
+		from, fromType := span.Start(), span.StartType()
+		for ; next.Before(span.End()); next = nextBoundary(boundary, next, loc) {
+			split = append(split, splitHandlerFunc(span, NewWithTypes(from, next, fromType, Open)))
+			from, fromType = next, Closed
+		}
+		split = append(split, splitHandlerFunc(span, NewWithTypes(from, span.End(), fromType, span.EndType())))
+	}
+	return split
+}
+
+// Split returns a list of Spans in which each of the contained spans is cut at every boundary which falls strictly
+// within it, as given by the boundary function in the location loc.
+// For example, given a list [A] where A runs from 22:00 on one day to 02:00 on the next, and DayBoundary, a list [B,C]
+// would be returned, with B covering A up to midnight, and C covering A from midnight. See SplitWithHandler.
+func (s Spans) Split(boundary Boundary, loc *time.Location) Spans {
+	return s.SplitWithHandler(boundary, loc, func(original, fragment Span) Span {
+		return fragment
+	})
+}
+
+// Returns the boundary after t, panicking if the boundary function doesn't return a time strictly after t, as Split
+// would otherwise keep cutting at the same place forever.
+func nextBoundary(boundary Boundary, t time.Time, loc *time.Location) time.Time {
+	next := boundary(t, loc)
+	if !next.After(t) {
+		panic(fmt.Errorf("spaniel: boundary after %v returned %v, which is not after it", t, next))
+	}
+	return next
+}
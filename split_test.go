@@ -0,0 +1,91 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+// Returns the start and end times of the spans in UTC, with their types, so that they can be compared regardless of
+// the locations of their times.
+func inUTC(spans timespan.Spans) timespan.Spans {
+	converted := timespan.Spans{}
+	for _, span := range spans {
+		converted = append(converted, timespan.NewWithTypes(span.Start().UTC(), span.End().UTC(), span.StartType(), span.EndType()))
+	}
+	return converted
+}
+
+func TestSplit(t *testing.T) {
+	london := loadLocation(t, "Europe/London")
+
+	t.Run("Should return spans within a boundary unchanged", func(t *testing.T) {
+		a := timespan.New(time.Date(2021, 6, 1, 1, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 23, 0, 0, 0, time.UTC))
+		b := timespan.New(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC))
+		c := timespan.NewInstant(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC))
+		spans := timespan.Spans{a, b, c}
+		expectEqual(t, spans.Split(timespan.DayBoundary, time.UTC), spans)
+	})
+
+	t.Run("Should split spans at each boundary", func(t *testing.T) {
+		a := timespan.NewWithTypes(time.Date(2021, 6, 1, 22, 0, 0, 0, time.UTC), time.Date(2021, 6, 4, 2, 0, 0, 0, time.UTC), timespan.Open, timespan.Closed)
+		expectEqual(t, inUTC(timespan.Spans{a}.Split(timespan.DayBoundary, time.UTC)), timespan.Spans{
+			timespan.NewWithTypes(time.Date(2021, 6, 1, 22, 0, 0, 0, time.UTC), time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), timespan.Open, timespan.Open),
+			timespan.New(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 4, 0, 0, 0, 0, time.UTC)),
+			timespan.NewWithTypes(time.Date(2021, 6, 4, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 4, 2, 0, 0, 0, time.UTC), timespan.Closed, timespan.Closed),
+		})
+	})
+
+	t.Run("Should split at local midnight when the clocks change", func(t *testing.T) {
+		a := timespan.New(time.Date(2021, 3, 27, 12, 0, 0, 0, time.UTC), time.Date(2021, 3, 29, 12, 0, 0, 0, time.UTC))
+		split := timespan.Spans{a}.Split(timespan.DayBoundary, london)
+		expectEqual(t, inUTC(split), timespan.Spans{
+			timespan.New(time.Date(2021, 3, 27, 12, 0, 0, 0, time.UTC), time.Date(2021, 3, 28, 0, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 3, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 28, 23, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 3, 28, 23, 0, 0, 0, time.UTC), time.Date(2021, 3, 29, 12, 0, 0, 0, time.UTC)),
+		})
+		expectEqual(t, split[1].End().Sub(split[1].Start()), 23*time.Hour)
+	})
+
+	t.Run("Should split at each hour when the clocks go back", func(t *testing.T) {
+		a := timespan.New(time.Date(2021, 10, 30, 23, 30, 0, 0, time.UTC), time.Date(2021, 10, 31, 2, 30, 0, 0, time.UTC))
+		expectEqual(t, inUTC(timespan.Spans{a}.Split(timespan.HourBoundary, london)), timespan.Spans{
+			timespan.New(time.Date(2021, 10, 30, 23, 30, 0, 0, time.UTC), time.Date(2021, 10, 31, 0, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 10, 31, 0, 0, 0, 0, time.UTC), time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC), time.Date(2021, 10, 31, 2, 0, 0, 0, time.UTC)),
+			timespan.New(time.Date(2021, 10, 31, 2, 0, 0, 0, time.UTC), time.Date(2021, 10, 31, 2, 30, 0, 0, time.UTC)),
+		})
+	})
+
+	t.Run("Should pass each fragment to the handler", func(t *testing.T) {
+		a := NewPropertyEvent(time.Date(2021, 6, 1, 22, 0, 0, 0, time.UTC), time.Date(2021, 6, 2, 2, 0, 0, 0, time.UTC), []string{"machine1"})
+		b := NewPropertyEvent(time.Date(2021, 6, 2, 3, 0, 0, 0, time.UTC), time.Date(2021, 6, 2, 4, 0, 0, 0, time.UTC), []string{"machine2"})
+
+		var originals timespan.Spans
+		split := timespan.Spans{a, b}.SplitWithHandler(timespan.DayBoundary, time.UTC, func(original, fragment timespan.Span) timespan.Span {
+			originals = append(originals, original)
+			return NewPropertyEvent(fragment.Start(), fragment.End(), original.(*PropertyEvent).Properties)
+		})
+
+		expectEqual(t, originals, timespan.Spans{a, a})
+		expectEqual(t, len(split), 3)
+		expectEqual(t, split[0].(*PropertyEvent).Properties, []string{"machine1"})
+		expectEqual(t, split[1].(*PropertyEvent).Properties, []string{"machine1"})
+		expectEqual(t, split[2], timespan.Span(b))
+	})
+
+	t.Run("Should panic rather than loop forever if a boundary doesn't advance", func(t *testing.T) {
+		stuck := func(t time.Time, loc *time.Location) time.Time {
+			return t.Truncate(time.Hour)
+		}
+		a := timespan.New(time.Date(2021, 6, 1, 22, 30, 0, 0, time.UTC), time.Date(2021, 6, 2, 2, 0, 0, 0, time.UTC))
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		timespan.Spans{a}.Split(stuck, time.UTC)
+	})
+}
@@ -0,0 +1,27 @@
+package spaniel
+
+import (
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// ErrOutOfOrder is returned by UnionStream when a span starts before the span preceding it.
+var ErrOutOfOrder = interval.ErrOutOfOrder
+
+// UnionStreamWithHandler merges a stream of spans, sorted by their start times, in the same way as UnionWithHandler,
+// without holding more than one merged span in memory at a time.
+// The spans are read by calling next until it returns false, as with a database cursor, or a channel read with
+// func() (Span, bool) { s, ok := <-c; return s, ok }. Each merged span is passed to emit as soon as no later span can
+// be merged with it. If emit returns an error, no more spans are read and that error is returned. If a span starts
+// before the span preceding it, ErrOutOfOrder is returned, and spans which have already been emitted are left as they
+// are. The provided handler is passed the source and destination spans, and the currently merged empty span.
+func UnionStreamWithHandler(next func() (Span, bool), unionHandlerFunc UnionHandlerFunc, emit func(Span) error) error {
+	return timeDomain.UnionStreamWithHandler(next, interval.UnionHandlerFunc[time.Time](unionHandlerFunc), emit)
+}
+
+// UnionStream merges a stream of spans, sorted by their start times, in the same way as Union, without holding more
+// than one merged span in memory at a time. See UnionStreamWithHandler.
+func UnionStream(next func() (Span, bool), emit func(Span) error) error {
+	return timeDomain.UnionStream(next, emit)
+}
@@ -0,0 +1,119 @@
+package spaniel_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+// Returns a function reading the spans one at a time, as UnionStream expects.
+func streamOf(spans timespan.Spans) func() (timespan.Span, bool) {
+	i := 0
+	return func() (timespan.Span, bool) {
+		if i >= len(spans) {
+			return nil, false
+		}
+		i++
+		return spans[i-1], true
+	}
+}
+
+func TestUnionStream(t *testing.T) {
+
+	collect := func(spans timespan.Spans) (timespan.Spans, error) {
+		merged := timespan.Spans{}
+		err := timespan.UnionStream(streamOf(spans), func(s timespan.Span) error {
+			merged = append(merged, s)
+			return nil
+		})
+		return merged, err
+	}
+
+	t.Run("Should emit nothing for an empty stream", func(t *testing.T) {
+		merged, err := collect(timespan.Spans{})
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, timespan.Spans{})
+	})
+
+	t.Run("Should merge overlapping and consecutive spans", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(30*time.Minute), now.Add(2*time.Hour))
+		c := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		d := timespan.New(now.Add(4*time.Hour), now.Add(5*time.Hour))
+		merged, err := collect(timespan.Spans{a, b, c, d})
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, timespan.Spans{timespan.New(now, now.Add(3*time.Hour)), d})
+	})
+
+	t.Run("Should emit merged spans as soon as they are final", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		read := 0
+		next := streamOf(timespan.Spans{a, b})
+		err := timespan.UnionStream(func() (timespan.Span, bool) {
+			read++
+			return next()
+		}, func(s timespan.Span) error {
+			if s == a && read != 2 {
+				t.Errorf("expected a to be emitted after reading b, read %d", read)
+			}
+			return nil
+		})
+		expectEqual(t, err, nil)
+	})
+
+	t.Run("Should return an error if spans are out of order", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(30*time.Minute), now.Add(90*time.Minute))
+		merged, err := collect(timespan.Spans{a, b, c})
+		expectEqual(t, err, timespan.ErrOutOfOrder)
+		expectEqual(t, merged, timespan.Spans{a})
+	})
+
+	t.Run("Should stop if emit returns an error", func(t *testing.T) {
+		a := timespan.New(now, now.Add(time.Hour))
+		b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+		c := timespan.New(now.Add(4*time.Hour), now.Add(5*time.Hour))
+		stop := errors.New("stop")
+		read := 0
+		next := streamOf(timespan.Spans{a, b, c})
+		err := timespan.UnionStream(func() (timespan.Span, bool) {
+			read++
+			return next()
+		}, func(s timespan.Span) error {
+			return stop
+		})
+		expectEqual(t, err, stop)
+		expectEqual(t, read, 2)
+	})
+
+	t.Run("Should match Union", func(t *testing.T) {
+		spans := randomSpans(rand.New(rand.NewSource(1)), 500)
+		sort.Stable(timespan.ByStart(spans))
+		merged, err := collect(spans)
+		expectEqual(t, err, nil)
+		expectEqual(t, merged, spans.Union())
+	})
+}
+
+func TestUnionStreamWithHandler(t *testing.T) {
+	a := NewPropertyEvent(now, now.Add(time.Hour), []string{"prop1"})
+	b := NewPropertyEvent(now.Add(30*time.Minute), now.Add(2*time.Hour), []string{"prop2"})
+
+	merged := timespan.Spans{}
+	err := timespan.UnionStreamWithHandler(streamOf(timespan.Spans{a, b}), func(mergeInto, mergeFrom, mergeSpan timespan.Span) timespan.Span {
+		into, from := mergeInto.(*PropertyEvent), mergeFrom.(*PropertyEvent)
+		return NewPropertyEvent(mergeSpan.Start(), mergeSpan.End(), append(into.Properties, from.Properties...))
+	}, func(s timespan.Span) error {
+		merged = append(merged, s)
+		return nil
+	})
+
+	expectEqual(t, err, nil)
+	expectEqual(t, merged, timespan.Spans{NewPropertyEvent(now, now.Add(2*time.Hour), []string{"prop1", "prop2"})})
+}
@@ -80,11 +80,17 @@ func (ts *TimeSpan) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 
-	ts.start = i.Start
-	ts.end = i.End
-	ts.startType = endPointInclusionUnmarhsal(i.StartIncluded)
-	ts.endType = endPointInclusionUnmarhsal(i.EndIncluded)
+	decoded := TimeSpan{
+		start:     i.Start,
+		end:       i.End,
+		startType: endPointInclusionUnmarhsal(i.StartIncluded),
+		endType:   endPointInclusionUnmarhsal(i.EndIncluded),
+	}
+	if err = Validate(decoded); err != nil {
+		return err
+	}
 
+	*ts = decoded
 	return
 }
 
@@ -121,3 +127,22 @@ func New(start time.Time, end time.Time) *TimeSpan {
 	}
 	return NewWithTypes(start, end, Closed, Open)
 }
+
+// NewWithTypesChecked creates a span in the same way as NewWithTypes, but returns an error from Validate if the span
+// would be invalid.
+func NewWithTypesChecked(start, end time.Time, startType, endType EndPointType) (*TimeSpan, error) {
+	ts := NewWithTypes(start, end, startType, endType)
+	if err := Validate(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// NewChecked creates a span in the same way as New, but returns an error from Validate if the span would be invalid.
+func NewChecked(start time.Time, end time.Time) (*TimeSpan, error) {
+	ts := New(start, end)
+	if err := Validate(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
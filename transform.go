@@ -0,0 +1,111 @@
+package spaniel
+
+import (
+	"time"
+)
+
+// Shift returns a span covering the same length of time as a, moved later by d, or earlier if d is negative. The
+// types of the end points are kept.
+func Shift(a Span, d time.Duration) *TimeSpan {
+	return NewWithTypes(a.Start().Add(d), a.End().Add(d), a.StartType(), a.EndType())
+}
+
+// Pad returns a span covering a, extended earlier by before and later by after. The types of the end points are kept.
+// Negative amounts shrink the span instead, which may leave it empty; see IsEmpty.
+func Pad(a Span, before, after time.Duration) *TimeSpan {
+	return NewWithTypes(a.Start().Add(-before), a.End().Add(after), a.StartType(), a.EndType())
+}
+
+// Shrink returns a span covering a, cut short at the start by before and at the end by after. The types of the end
+// points are kept. Shrinking a span by more than its length leaves it empty; see IsEmpty.
+func Shrink(a Span, before, after time.Duration) *TimeSpan {
+	return Pad(a, -before, -after)
+}
+
+// Scale returns a span with its start and end moved away from the pivot by the given factor, so that its length is
+// multiplied by the factor. For example, scaling [2,4) about 0 by a factor of 2 results in [4,8), and scaling it about
+// its start by a factor of 0.5 results in [2,3). The types of the end points are kept. A negative factor leaves the
+// span ending before it starts, so it is empty; see IsEmpty.
+func Scale(a Span, pivot time.Time, factor float64) *TimeSpan {
+	scale := func(t time.Time) time.Time {
+		return pivot.Add(time.Duration(float64(t.Sub(pivot)) * factor))
+	}
+	return NewWithTypes(scale(a.Start()), scale(a.End()), a.StartType(), a.EndType())
+}
+
+// TransformHandlerFunc is used by the WithHandler variants of Shift, Pad, Shrink and Scale to allow for custom
+// functionality when a span is transformed. It is passed the original span, and the span representing the
+// transformed time.
+type TransformHandlerFunc func(original, transformed Span) Span
+
+// Returns a list of the spans transformed by transform and passed to the handler, leaving out any which are empty.
+func (s Spans) transform(transform func(Span) *TimeSpan, transformHandlerFunc TransformHandlerFunc) Spans {
+	transformed := Spans{}
+	for _, span := range s {
+		t := transform(span)
+		if IsEmpty(t) {
+			continue
+		}
+		transformed = append(transformed, transformHandlerFunc(span, t))
+	}
+	return transformed
+}
+
+// ShiftWithHandler returns a list of the contained spans moved later by d, or earlier if d is negative, as by Shift.
+// The provided handler is passed each original span, and the span representing the time it has been moved to.
+func (s Spans) ShiftWithHandler(d time.Duration, transformHandlerFunc TransformHandlerFunc) Spans {
+	return s.transform(func(a Span) *TimeSpan { return Shift(a, d) }, transformHandlerFunc)
+}
+
+// Shift returns a list of the contained spans moved later by d, or earlier if d is negative, as by Shift.
+func (s Spans) Shift(d time.Duration) Spans {
+	return s.ShiftWithHandler(d, func(original, transformed Span) Span {
+		return transformed
+	})
+}
+
+// PadWithHandler returns a list of the contained spans extended earlier by before and later by after, as by Pad.
+// Spans which are left empty by negative amounts are removed. The provided handler is passed each original span, and
+// the span representing the time it has been extended to.
+func (s Spans) PadWithHandler(before, after time.Duration, transformHandlerFunc TransformHandlerFunc) Spans {
+	return s.transform(func(a Span) *TimeSpan { return Pad(a, before, after) }, transformHandlerFunc)
+}
+
+// Pad returns a list of the contained spans extended earlier by before and later by after, as by Pad. Spans which are
+// left empty by negative amounts are removed.
+// For example, padding a list of alerts by five minutes either side gives the windows of time around them.
+func (s Spans) Pad(before, after time.Duration) Spans {
+	return s.PadWithHandler(before, after, func(original, transformed Span) Span {
+		return transformed
+	})
+}
+
+// ShrinkWithHandler returns a list of the contained spans cut short at the start by before and at the end by after, as
+// by Shrink. Spans which are left empty are removed. The provided handler is passed each original span, and the span
+// representing the time it has been cut down to.
+func (s Spans) ShrinkWithHandler(before, after time.Duration, transformHandlerFunc TransformHandlerFunc) Spans {
+	return s.transform(func(a Span) *TimeSpan { return Shrink(a, before, after) }, transformHandlerFunc)
+}
+
+// Shrink returns a list of the contained spans cut short at the start by before and at the end by after, as by
+// Shrink. Spans which are left empty are removed.
+func (s Spans) Shrink(before, after time.Duration) Spans {
+	return s.ShrinkWithHandler(before, after, func(original, transformed Span) Span {
+		return transformed
+	})
+}
+
+// ScaleWithHandler returns a list of the contained spans scaled about the pivot by the given factor, as by Scale.
+// Spans which are left empty are removed. The provided handler is passed each original span, and the span
+// representing the time it has been scaled to.
+func (s Spans) ScaleWithHandler(pivot time.Time, factor float64, transformHandlerFunc TransformHandlerFunc) Spans {
+	return s.transform(func(a Span) *TimeSpan { return Scale(a, pivot, factor) }, transformHandlerFunc)
+}
+
+// Scale returns a list of the contained spans scaled about the pivot by the given factor, as by Scale. Spans which are
+// left empty are removed.
+func (s Spans) Scale(pivot time.Time, factor float64) Spans {
+	return s.ScaleWithHandler(pivot, factor, func(original, transformed Span) Span {
+		return transformed
+	})
+}
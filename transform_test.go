@@ -0,0 +1,74 @@
+package spaniel_test
+
+import (
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestTransforms(t *testing.T) {
+	a := timespan.NewWithTypes(now, now.Add(time.Hour), timespan.Open, timespan.Closed)
+	b := timespan.New(now.Add(2*time.Hour), now.Add(2*time.Hour+10*time.Minute))
+	c := timespan.NewInstant(now.Add(3 * time.Hour))
+	spans := timespan.Spans{a, b, c}
+
+	t.Run("Should shift spans keeping their types", func(t *testing.T) {
+		expectEqual(t, spans.Shift(-time.Hour), timespan.Spans{
+			timespan.NewWithTypes(now.Add(-time.Hour), now, timespan.Open, timespan.Closed),
+			timespan.New(now.Add(time.Hour), now.Add(time.Hour+10*time.Minute)),
+			timespan.NewInstant(now.Add(2 * time.Hour)),
+		})
+	})
+
+	t.Run("Should pad spans", func(t *testing.T) {
+		expectEqual(t, spans.Pad(5*time.Minute, 10*time.Minute), timespan.Spans{
+			timespan.NewWithTypes(now.Add(-5*time.Minute), now.Add(70*time.Minute), timespan.Open, timespan.Closed),
+			timespan.New(now.Add(115*time.Minute), now.Add(140*time.Minute)),
+			timespan.NewWithTypes(now.Add(175*time.Minute), now.Add(190*time.Minute), timespan.Closed, timespan.Closed),
+		})
+	})
+
+	t.Run("Should drop spans which are shrunk until empty", func(t *testing.T) {
+		expectEqual(t, spans.Shrink(5*time.Minute, 5*time.Minute), timespan.Spans{
+			timespan.NewWithTypes(now.Add(5*time.Minute), now.Add(55*time.Minute), timespan.Open, timespan.Closed),
+		})
+	})
+
+	t.Run("Should keep spans shrunk to a Closed instant", func(t *testing.T) {
+		closed := timespan.NewWithTypes(now, now.Add(10*time.Minute), timespan.Closed, timespan.Closed)
+		expectEqual(t, timespan.Spans{closed}.Shrink(5*time.Minute, 5*time.Minute), timespan.Spans{
+			timespan.NewWithTypes(now.Add(5*time.Minute), now.Add(5*time.Minute), timespan.Closed, timespan.Closed),
+		})
+	})
+
+	t.Run("Should scale spans about a pivot", func(t *testing.T) {
+		expectEqual(t, spans.Scale(now, 2), timespan.Spans{
+			timespan.NewWithTypes(now, now.Add(2*time.Hour), timespan.Open, timespan.Closed),
+			timespan.New(now.Add(4*time.Hour), now.Add(4*time.Hour+20*time.Minute)),
+			timespan.NewInstant(now.Add(6 * time.Hour)),
+		})
+		expectEqual(t, timespan.Scale(b, b.Start(), 0.5), timespan.New(b.Start(), b.Start().Add(5*time.Minute)))
+	})
+
+	t.Run("Should drop spans reversed by a negative factor", func(t *testing.T) {
+		expectEqual(t, spans.Scale(now, -1), timespan.Spans{timespan.NewInstant(now.Add(-3 * time.Hour))})
+	})
+
+	t.Run("Should pass each original span to the handler", func(t *testing.T) {
+		event := NewPropertyEvent(now, now.Add(time.Hour), []string{"machine1"})
+		var originals timespan.Spans
+		shifted := timespan.Spans{event, c}.ShiftWithHandler(time.Hour, func(original, transformed timespan.Span) timespan.Span {
+			originals = append(originals, original)
+			if p, ok := original.(*PropertyEvent); ok {
+				return NewPropertyEvent(transformed.Start(), transformed.End(), p.Properties)
+			}
+			return transformed
+		})
+		expectEqual(t, originals, timespan.Spans{event, c})
+		expectEqual(t, shifted, timespan.Spans{
+			NewPropertyEvent(now.Add(time.Hour), now.Add(2*time.Hour), []string{"machine1"}),
+			timespan.NewInstant(now.Add(4 * time.Hour)),
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package spaniel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrReversed is returned when a span ends before it starts.
+	ErrReversed = errors.New("span ends before it starts")
+	// ErrEmpty is returned when a span starts and ends at the same time, but isn't Closed at both ends.
+	ErrEmpty = errors.New("span is empty")
+)
+
+// InvalidSpanError reports a span within a list which failed validation, and why.
+type InvalidSpanError struct {
+	Index int
+	Span  Span
+	Err   error
+}
+
+// Error implements error
+func (e *InvalidSpanError) Error() string {
+	return fmt.Sprintf("span %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the reason the span failed validation
+func (e *InvalidSpanError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidSpansError reports every span within a list which failed validation, in the order they appear in the list.
+type InvalidSpansError []*InvalidSpanError
+
+// Error implements error
+func (e InvalidSpansError) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid spans: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Validate returns ErrReversed if the span ends before it starts, or ErrEmpty if it is otherwise empty as reported by
+// IsEmpty, such as (1,1] which starts and ends at the same time without including it. Otherwise, it returns nil.
+func Validate(s Span) error {
+	if s.End().Before(s.Start()) {
+		return ErrReversed
+	}
+	if IsEmpty(s) {
+		return ErrEmpty
+	}
+	return nil
+}
+
+// Validate checks each of the contained spans with Validate, and returns an InvalidSpansError reporting all of those
+// which are invalid, or nil if they are all valid.
+func (s Spans) Validate() error {
+	var invalid InvalidSpansError
+	for i, span := range s {
+		if err := Validate(span); err != nil {
+			invalid = append(invalid, &InvalidSpanError{i, span, err})
+		}
+	}
+	if len(invalid) > 0 {
+		return invalid
+	}
+	return nil
+}
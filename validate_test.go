@@ -0,0 +1,126 @@
+package spaniel_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestValidate(t *testing.T) {
+
+	for _, tt := range []struct {
+		name     string
+		span     timespan.Span
+		expected error
+	}{
+		{name: "span", span: timespan.New(t1, t2), expected: nil},
+		{name: "instant", span: timespan.NewInstant(t1), expected: nil},
+		{name: "half open instant", span: timespan.NewWithTypes(t1, t1, timespan.Closed, timespan.Open), expected: timespan.ErrEmpty},
+		{name: "open instant", span: timespan.NewWithTypes(t1, t1, timespan.Open, timespan.Open), expected: timespan.ErrEmpty},
+		{name: "reversed", span: timespan.New(t2, t1), expected: timespan.ErrReversed},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := timespan.Validate(tt.span); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSpansValidate(t *testing.T) {
+
+	t.Run("Should return nil if all spans are valid", func(t *testing.T) {
+		spans := timespan.Spans{timespan.New(t1, t2), timespan.NewInstant(t3)}
+		if err := spans.Validate(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Should report every invalid span with its index", func(t *testing.T) {
+		reversed := timespan.New(t2, t1)
+		empty := timespan.NewWithTypes(t3, t3, timespan.Open, timespan.Open)
+		spans := timespan.Spans{reversed, timespan.New(t1, t2), empty}
+
+		err := spans.Validate()
+		invalid, ok := err.(timespan.InvalidSpansError)
+		if !ok {
+			t.Fatalf("expected an InvalidSpansError, got %v", err)
+		}
+		expectEqual(t, invalid, timespan.InvalidSpansError{
+			{Index: 0, Span: reversed, Err: timespan.ErrReversed},
+			{Index: 2, Span: empty, Err: timespan.ErrEmpty},
+		})
+		expectEqual(t, err.Error(), "2 invalid spans: span 0: span ends before it starts; span 2: span is empty")
+	})
+}
+
+func TestCheckedConstructors(t *testing.T) {
+
+	t.Run("Should create a valid span", func(t *testing.T) {
+		ts, err := timespan.NewChecked(t1, t2)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expectEqual(t, ts, timespan.New(t1, t2))
+	})
+
+	t.Run("Should reject a reversed span", func(t *testing.T) {
+		ts, err := timespan.NewChecked(t2, t1)
+		if err != timespan.ErrReversed || ts != nil {
+			t.Errorf("expected ErrReversed, got %v", err)
+		}
+	})
+
+	t.Run("Should create a valid span with types", func(t *testing.T) {
+		ts, err := timespan.NewWithTypesChecked(t1, t2, timespan.Open, timespan.Closed)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expectEqual(t, ts, timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Closed))
+	})
+
+	t.Run("Should reject an open instant", func(t *testing.T) {
+		ts, err := timespan.NewWithTypesChecked(t1, t1, timespan.Open, timespan.Open)
+		if err != timespan.ErrEmpty || ts != nil {
+			t.Errorf("expected ErrEmpty, got %v", err)
+		}
+	})
+}
+
+func TestTimeSpanJSON(t *testing.T) {
+
+	t.Run("Should round trip a span", func(t *testing.T) {
+		original := timespan.NewWithTypes(t1, t2, timespan.Open, timespan.Closed)
+		b, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var decoded timespan.TimeSpan
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expectEqual(t, &decoded, original)
+	})
+
+	t.Run("Should reject a reversed span", func(t *testing.T) {
+		b := []byte(`{"start":"2018-01-30T01:00:00Z","end":"2018-01-30T00:00:00Z","start_included":true,"end_included":false}`)
+		var decoded timespan.TimeSpan
+		if err := json.Unmarshal(b, &decoded); err != timespan.ErrReversed {
+			t.Errorf("expected ErrReversed, got %v", err)
+		}
+		expectEqual(t, decoded.Start(), time.Time{})
+	})
+
+	t.Run("Should reject an open instant", func(t *testing.T) {
+		b := []byte(`{"start":"2018-01-30T00:00:00Z","end":"2018-01-30T00:00:00Z","start_included":false,"end_included":false}`)
+		var decoded timespan.TimeSpan
+		if err := json.Unmarshal(b, &decoded); err != timespan.ErrEmpty {
+			t.Errorf("expected ErrEmpty, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package spaniel
+
+import (
+	"time"
+
+	"github.com/senseyeio/spaniel/interval"
+)
+
+// Window returns the contained spans which overlap the window, in the same order, taking the types of their end points
+// into account. The spans must be sorted by start time, as by ByStart, but may overlap one another. The spans starting
+// after the window are skipped by binary search, but those starting before the end of the window are each compared
+// with it, so for spans which don't overlap one another, such as those returned by Union, WindowDisjoint is faster,
+// and for repeated queries of spans which do, an Index is.
+// For example, given a list [A,B,C] where only B and C overlap the window, a list [B,C] would be returned.
+func (s Spans) Window(window Span) Spans {
+	return Spans(timeDomain.Window(spans(s), window))
+}
+
+// WindowDisjoint returns the contained spans which overlap the window in the same way as Window. The spans must be
+// sorted by start time and must not overlap one another, as returned by Union, so that both the first and the last of
+// them to overlap the window can be found by binary search, in O(log n + k) time for k spans returned. The spans
+// returned can be cut to the window with Clip.
+// For example, given a list [A,B,C] where only B and C overlap the window, a list [B,C] would be returned.
+func (s Spans) WindowDisjoint(window Span) Spans {
+	return Spans(timeDomain.WindowDisjoint(spans(s), window))
+}
+
+// WindowClipped returns the contained spans which overlap the window in the same way as Window, but with any which
+// extend outside of the window cut to its bounds. Where a span is cut, the type of the window's end point is used,
+// and where a span and the window start or end at the same time, the tighter of their types is used, as by
+// Intersection. Spans which lie within the window are returned unchanged.
+// For example, given a list [A,B,C] where B lies within the window, and C starts within it but finishes after it, a
+// list [B,D] would be returned, with the span D covering C up to the end of the window.
+func (s Spans) WindowClipped(window Span) Spans {
+	return Spans(timeDomain.WindowClipped(spans(s), window))
+}
+
+// ClipHandlerFunc is used by ClipWithHandler to allow for custom functionality when a span is cut to fit within a
+// window. It is passed the span which has been cut, and the span representing the part of it within the window.
+type ClipHandlerFunc func(original, clipped Span) Span
+
+// ClipWithHandler returns a list of the contained spans cut to fit within the window, in the same order, leaving out
+// any which lie entirely outside of it. Where a span is cut, the type of the window's end point is used, and where a
+// span and the window start or end at the same time, the tighter of their types is used, as by Intersection. Unlike
+// WindowClipped, the spans don't need to be sorted.
+// For example, given a list [A,B,C] where A starts before the window and finishes within it, B lies within the window
+// and C lies after it, a list [D,B] would be returned, with the span D covering A from the start of the window. The
+// provided handler is passed each span which is cut, and the span representing the part of it within the window.
+// Spans which lie within the window are returned unchanged.
+func (s Spans) ClipWithHandler(window Span, clipHandlerFunc ClipHandlerFunc) Spans {
+	return Spans(timeDomain.ClipWithHandler(spans(s), window, interval.ClipHandlerFunc[time.Time](clipHandlerFunc)))
+}
+
+// Clip returns a list of the contained spans cut to fit within the window, in the same order, leaving out any which
+// lie entirely outside of it.
+// For example, given a list [A,B,C] where A starts before the window and finishes within it, B lies within the window
+// and C lies after it, a list [D,B] would be returned, with the span D covering A from the start of the window.
+func (s Spans) Clip(window Span) Spans {
+	return Spans(timeDomain.Clip(spans(s), window))
+}
@@ -0,0 +1,159 @@
+package spaniel_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	timespan "github.com/senseyeio/spaniel"
+)
+
+func TestWindow(t *testing.T) {
+	a := timespan.New(now, now.Add(time.Hour))
+	b := timespan.New(now.Add(2*time.Hour), now.Add(3*time.Hour))
+	c := timespan.NewInstant(now.Add(4 * time.Hour))
+	d := timespan.New(now.Add(5*time.Hour), now.Add(7*time.Hour))
+	spans := timespan.Spans{a, b, c, d}
+
+	for _, tt := range []struct {
+		name            string
+		window          timespan.Span
+		expected        timespan.Spans
+		expectedClipped timespan.Spans
+	}{
+		{
+			name:            "Should return nothing for a window before the spans",
+			window:          timespan.New(now.Add(-time.Hour), now),
+			expected:        timespan.Spans{},
+			expectedClipped: timespan.Spans{},
+		},
+		{
+			name:            "Should return nothing for a window between spans",
+			window:          timespan.New(now.Add(time.Hour), now.Add(2*time.Hour)),
+			expected:        timespan.Spans{},
+			expectedClipped: timespan.Spans{},
+		},
+		{
+			name:            "Should return nothing for a window after the spans",
+			window:          timespan.New(now.Add(8*time.Hour), now.Add(9*time.Hour)),
+			expected:        timespan.Spans{},
+			expectedClipped: timespan.Spans{},
+		},
+		{
+			name:            "Should return the spans within the window",
+			window:          timespan.New(now.Add(2*time.Hour), now.Add(5*time.Hour)),
+			expected:        timespan.Spans{b, c},
+			expectedClipped: timespan.Spans{b, c},
+		},
+		{
+			name:            "Should include a span touching a Closed end",
+			window:          timespan.NewWithTypes(now.Add(2*time.Hour), now.Add(5*time.Hour), timespan.Closed, timespan.Closed),
+			expected:        timespan.Spans{b, c, d},
+			expectedClipped: timespan.Spans{b, c, timespan.NewInstant(now.Add(5 * time.Hour))},
+		},
+		{
+			name:     "Should clip spans extending outside the window",
+			window:   timespan.NewWithTypes(now.Add(30*time.Minute), now.Add(6*time.Hour), timespan.Open, timespan.Closed),
+			expected: timespan.Spans{a, b, c, d},
+			expectedClipped: timespan.Spans{
+				timespan.NewWithTypes(now.Add(30*time.Minute), now.Add(time.Hour), timespan.Open, timespan.Open),
+				b,
+				c,
+				timespan.NewWithTypes(now.Add(5*time.Hour), now.Add(6*time.Hour), timespan.Closed, timespan.Closed),
+			},
+		},
+		{
+			name:            "Should return an instant within the window",
+			window:          timespan.NewInstant(now.Add(4 * time.Hour)),
+			expected:        timespan.Spans{c},
+			expectedClipped: timespan.Spans{c},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			expectEqual(t, spans.Window(tt.window), tt.expected)
+			expectEqual(t, spans.WindowDisjoint(tt.window), tt.expected)
+			expectEqual(t, spans.WindowClipped(tt.window), tt.expectedClipped)
+		})
+	}
+
+	t.Run("Should return the same spans as comparing each span with the window", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			merged := randomSpans(r, 20).Union()
+			window := randomSpans(r, 1)[0]
+
+			expected := timespan.Spans{}
+			for _, span := range merged {
+				if timespan.Overlaps(span, window) {
+					expected = append(expected, span)
+				}
+			}
+			expectEqual(t, merged.Window(window), expected)
+			expectEqual(t, merged.WindowDisjoint(window), expected)
+		}
+	})
+
+	t.Run("Should return a span starting long before the window which overlaps it", func(t *testing.T) {
+		long := timespan.New(now, now.Add(100*time.Hour))
+		overlapping := timespan.Spans{
+			long,
+			timespan.New(now.Add(time.Hour), now.Add(2*time.Hour)),
+			timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour)),
+			timespan.New(now.Add(5*time.Hour), now.Add(6*time.Hour)),
+		}
+		window := timespan.New(now.Add(50*time.Hour), now.Add(60*time.Hour))
+		expectEqual(t, overlapping.Window(window), timespan.Spans{long})
+		expectEqual(t, overlapping.WindowClipped(window), timespan.Spans{window})
+	})
+
+	t.Run("Should return the same spans as comparing each overlapping span with the window", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			sorted := randomSpans(r, 20)
+			sort.Stable(timespan.ByStart(sorted))
+			window := randomSpans(r, 1)[0]
+
+			expected := timespan.Spans{}
+			for _, span := range sorted {
+				if timespan.Overlaps(span, window) {
+					expected = append(expected, span)
+				}
+			}
+			expectEqual(t, sorted.Window(window), expected)
+		}
+	})
+}
+
+func TestClip(t *testing.T) {
+	a := timespan.New(now, now.Add(2*time.Hour))
+	b := timespan.New(now.Add(3*time.Hour), now.Add(4*time.Hour))
+	c := timespan.New(now.Add(5*time.Hour), now.Add(6*time.Hour))
+	d := timespan.NewWithTypes(now.Add(3*time.Hour), now.Add(7*time.Hour), timespan.Open, timespan.Closed)
+	window := timespan.NewWithTypes(now.Add(time.Hour), now.Add(5*time.Hour), timespan.Open, timespan.Closed)
+
+	t.Run("Should clip spans to the window, keeping their order", func(t *testing.T) {
+		spans := timespan.Spans{d, c, b, a}
+		expectEqual(t, spans.Clip(window), timespan.Spans{
+			timespan.NewWithTypes(now.Add(3*time.Hour), now.Add(5*time.Hour), timespan.Open, timespan.Closed),
+			timespan.NewInstant(now.Add(5 * time.Hour)),
+			b,
+			timespan.NewWithTypes(now.Add(time.Hour), now.Add(2*time.Hour), timespan.Open, timespan.Open),
+		})
+	})
+
+	t.Run("Should discard spans outside the window", func(t *testing.T) {
+		outside := timespan.New(now.Add(5*time.Hour+time.Minute), now.Add(6*time.Hour))
+		touching := timespan.New(now, now.Add(time.Hour))
+		expectEqual(t, timespan.Spans{outside, touching}.Clip(window), timespan.Spans{})
+	})
+
+	t.Run("Should pass only the spans which are cut to the handler", func(t *testing.T) {
+		var originals timespan.Spans
+		timespan.Spans{a, b}.ClipWithHandler(window, func(original, clipped timespan.Span) timespan.Span {
+			originals = append(originals, original)
+			return clipped
+		})
+		expectEqual(t, originals, timespan.Spans{a})
+	})
+}